@@ -46,7 +46,11 @@ helm.sh/chart: {{ include "chart" . | quote }}
 {{- end -}}
 
 {{- define "kyverno-policy-operator.crdAdoptionAnnotations" -}}
+{{- if .Values.crds.install -}}
+"helm.sh/hook": "post-install,post-upgrade"
+{{- else -}}
 "helm.sh/hook": "post-upgrade"
+{{- end }}
 "helm.sh/hook-delete-policy": "before-hook-creation,hook-succeeded"
 {{- end -}}
 
@@ -0,0 +1,284 @@
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov2 "github.com/kyverno/kyverno/api/kyverno/v2"
+	pssutils "github.com/kyverno/kyverno/pkg/pss/utils"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// Annotations read from Giant Swarm PolicyExceptions and PolicyManifests to extend the Policy API.
+const (
+	// RulesAnnotation restricts the exception to a subset of rules per policy.
+	// The value is a JSON object mapping policy names to rule names, e.g. {"disallow-privileged-containers": ["restrict-privileged-containers"]}.
+	RulesAnnotation = "policy.giantswarm.io/rules"
+	// ExpiresAtAnnotation sets an RFC 3339 timestamp after which the exception is no longer granted.
+	ExpiresAtAnnotation = "policy.giantswarm.io/expires-at"
+	// ResolutionModeAnnotation controls how referenced policies missing from the PolicyCache are handled.
+	ResolutionModeAnnotation = "policy.giantswarm.io/resolution-mode"
+	// SelectorTargetsAnnotation adds targets matched by labels instead of names.
+	// The value is a JSON list of SelectorTarget objects.
+	SelectorTargetsAnnotation = "policy.giantswarm.io/selector-targets"
+	// NameMatchingAnnotation chooses how target names are matched.
+	// The value is a comma-separated list of a default mode and per-target overrides, e.g. "owner,1=prefix".
+	NameMatchingAnnotation = "policy.giantswarm.io/name-matching"
+	// ConditionsAnnotation restricts the exception to the requests matching Kyverno conditions.
+	// The value is a JSON object with any and/or all condition lists, e.g. {"all": [{"key": "{{ request.operation }}", "operator": "Equals", "value": "CREATE"}]}.
+	ConditionsAnnotation = "policy.giantswarm.io/conditions"
+	// PodSecurityAnnotation exempts individual Pod Security Standards controls instead of whole rules.
+	// The value is a JSON list of Kyverno PodSecurityStandard objects, e.g. [{"controlName": "Capabilities", "images": ["ghcr.io/giantswarm/*"], "restrictedField": "spec.containers[*].securityContext.capabilities.add", "values": ["NET_ADMIN"]}].
+	PodSecurityAnnotation = "policy.giantswarm.io/pod-security"
+	// CreatedByAnnotation records who requested the exception. The webhook sets it to the creating user unless the client,
+	// e.g. GitOps tooling, named the requester, and keeps it unchanged afterwards. It is copied to the generated Kyverno PolicyExceptions.
+	CreatedByAnnotation = "policy.giantswarm.io/created-by"
+	// ApprovedSpecHashAnnotation holds the spec hash an approver accepted, see SpecHash.
+	ApprovedSpecHashAnnotation = "policy.giantswarm.io/approved-spec-hash"
+	// ApprovedByAnnotation records the identity of the approver.
+	ApprovedByAnnotation = "policy.giantswarm.io/approved-by"
+)
+
+// AnnotationPrefix is shared by every annotation extending the Policy API.
+const AnnotationPrefix = "policy.giantswarm.io/"
+
+// Values accepted by the name-matching annotation.
+const (
+	// NameMatchingOwner matches the target and the objects created by its controllers, e.g. name-<hash> for ReplicaSets. This is the default.
+	NameMatchingOwner = "owner"
+	// NameMatchingExact matches the target names as they are.
+	NameMatchingExact = "exact"
+	// NameMatchingPrefix matches every object whose name starts with a target name.
+	NameMatchingPrefix = "prefix"
+)
+
+// NameMatching holds the name matching mode of every target.
+type NameMatching struct {
+	// Default applies to the targets without an override.
+	Default string
+	// Overrides maps target indexes to their mode.
+	Overrides map[int]string
+}
+
+// shift returns the name matching of the targets following the first offset targets.
+func (n NameMatching) shift(offset int) NameMatching {
+	shifted := NameMatching{Default: n.Default, Overrides: make(map[int]string, len(n.Overrides))}
+	for index, mode := range n.Overrides {
+		if index >= offset {
+			shifted.Overrides[index-offset] = mode
+		}
+	}
+
+	return shifted
+}
+
+// modeFor returns the name matching mode of the target at index.
+func (n NameMatching) modeFor(index int) string {
+	if mode, ok := n.Overrides[index]; ok {
+		return mode
+	}
+	if n.Default == "" {
+		return NameMatchingOwner
+	}
+
+	return n.Default
+}
+
+// SelectorTarget matches the resources of a kind by their labels or the labels of their namespace.
+type SelectorTarget struct {
+	Kind              string                `json:"kind"`
+	Namespaces        []string              `json:"namespaces,omitempty"`
+	Selector          *metav1.LabelSelector `json:"selector,omitempty"`
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+}
+
+// Values accepted by the resolution-mode annotation.
+const (
+	// ResolutionModeStrict skips the translation until every referenced policy is found. This is the default.
+	ResolutionModeStrict = "strict"
+	// ResolutionModePartial translates the policies which were found and reports the missing ones.
+	ResolutionModePartial = "partial"
+)
+
+// parseRuleSelection reads the rules annotation and returns the selected rules per policy.
+// A nil map means every rule of every policy is exempted.
+func parseRuleSelection(annotations map[string]string) (map[string][]string, error) {
+	value, ok := annotations[RulesAnnotation]
+	if !ok {
+		return nil, nil
+	}
+
+	ruleSelection := make(map[string][]string)
+	if err := json.Unmarshal([]byte(value), &ruleSelection); err != nil {
+		return nil, fmt.Errorf("invalid %s annotation: %w", RulesAnnotation, err)
+	}
+
+	return ruleSelection, nil
+}
+
+// parseExpiry reads the expires-at annotation. A nil time means the exception never expires.
+func parseExpiry(annotations map[string]string) (*time.Time, error) {
+	value, ok := annotations[ExpiresAtAnnotation]
+	if !ok {
+		return nil, nil
+	}
+
+	expiresAt, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s annotation: %w", ExpiresAtAnnotation, err)
+	}
+
+	return &expiresAt, nil
+}
+
+// parseResolutionMode reads the resolution-mode annotation, defaulting to ResolutionModeStrict.
+func parseResolutionMode(annotations map[string]string) (string, error) {
+	value, ok := annotations[ResolutionModeAnnotation]
+	if !ok {
+		return ResolutionModeStrict, nil
+	}
+
+	switch value {
+	case ResolutionModeStrict, ResolutionModePartial:
+		return value, nil
+	default:
+		return "", fmt.Errorf("invalid %s annotation: unknown mode %q, expected %q or %q", ResolutionModeAnnotation, value, ResolutionModeStrict, ResolutionModePartial)
+	}
+}
+
+// parseSelectorTargets reads the selector-targets annotation and validates the label selectors.
+func parseSelectorTargets(annotations map[string]string) ([]SelectorTarget, error) {
+	value, ok := annotations[SelectorTargetsAnnotation]
+	if !ok {
+		return nil, nil
+	}
+
+	var selectorTargets []SelectorTarget
+	if err := json.Unmarshal([]byte(value), &selectorTargets); err != nil {
+		return nil, fmt.Errorf("invalid %s annotation: %w", SelectorTargetsAnnotation, err)
+	}
+
+	for i, selectorTarget := range selectorTargets {
+		if selectorTarget.Kind == "" {
+			return nil, fmt.Errorf("invalid %s annotation: target %d has no kind", SelectorTargetsAnnotation, i)
+		}
+		// A target without selectors would match every resource of its kind
+		if selectorTarget.Selector == nil && selectorTarget.NamespaceSelector == nil {
+			return nil, fmt.Errorf("invalid %s annotation: target %d needs a selector or a namespaceSelector", SelectorTargetsAnnotation, i)
+		}
+		for _, labelSelector := range []*metav1.LabelSelector{selectorTarget.Selector, selectorTarget.NamespaceSelector} {
+			if _, err := metav1.LabelSelectorAsSelector(labelSelector); err != nil {
+				return nil, fmt.Errorf("invalid %s annotation: target %d: %w", SelectorTargetsAnnotation, i, err)
+			}
+		}
+	}
+
+	return selectorTargets, nil
+}
+
+// parseNameMatching reads the name-matching annotation for targetCount targets.
+func parseNameMatching(annotations map[string]string, targetCount int) (NameMatching, error) {
+	nameMatching := NameMatching{Default: NameMatchingOwner}
+
+	value, ok := annotations[NameMatchingAnnotation]
+	if !ok {
+		return nameMatching, nil
+	}
+
+	for entry := range strings.SplitSeq(value, ",") {
+		entry = strings.TrimSpace(entry)
+		index, mode, isOverride := strings.Cut(entry, "=")
+		if !isOverride {
+			mode = entry
+		}
+
+		switch mode {
+		case NameMatchingOwner, NameMatchingExact, NameMatchingPrefix:
+		default:
+			return NameMatching{}, fmt.Errorf("invalid %s annotation: unknown mode %q, expected %q, %q or %q",
+				NameMatchingAnnotation, mode, NameMatchingOwner, NameMatchingExact, NameMatchingPrefix)
+		}
+
+		if !isOverride {
+			nameMatching.Default = mode
+			continue
+		}
+
+		targetIndex, err := strconv.Atoi(strings.TrimSpace(index))
+		if err != nil || targetIndex < 0 || targetIndex >= targetCount {
+			return NameMatching{}, fmt.Errorf("invalid %s annotation: %q is not a target index", NameMatchingAnnotation, index)
+		}
+		if nameMatching.Overrides == nil {
+			nameMatching.Overrides = make(map[int]string)
+		}
+		nameMatching.Overrides[targetIndex] = mode
+	}
+
+	return nameMatching, nil
+}
+
+// parseConditions reads the conditions annotation and validates the operators.
+// Nil conditions mean the exception applies to every matched request.
+func parseConditions(annotations map[string]string) (*kyvernov2.AnyAllConditions, error) {
+	value, ok := annotations[ConditionsAnnotation]
+	if !ok {
+		return nil, nil
+	}
+
+	conditions := &kyvernov2.AnyAllConditions{}
+	if err := json.Unmarshal([]byte(value), conditions); err != nil {
+		return nil, fmt.Errorf("invalid %s annotation: %w", ConditionsAnnotation, err)
+	}
+
+	if len(conditions.AnyConditions) == 0 && len(conditions.AllConditions) == 0 {
+		return nil, fmt.Errorf("invalid %s annotation: at least one any or all condition is required", ConditionsAnnotation)
+	}
+
+	for list, conditionList := range map[string][]kyvernov2.Condition{"any": conditions.AnyConditions, "all": conditions.AllConditions} {
+		for i, condition := range conditionList {
+			if condition.RawKey == nil {
+				return nil, fmt.Errorf("invalid %s annotation: %s condition %d has no key", ConditionsAnnotation, list, i)
+			}
+			// Kyverno rejects the whole PolicyException for unknown operators
+			if _, known := kyvernov2.ConditionOperators[string(condition.Operator)]; !known {
+				return nil, fmt.Errorf("invalid %s annotation: %s condition %d has unsupported operator %q", ConditionsAnnotation, list, i, condition.Operator)
+			}
+		}
+	}
+
+	return conditions, nil
+}
+
+// parsePodSecurity reads the pod-security annotation and validates the controls the same way Kyverno does.
+// Nil controls mean the exempted rules are skipped entirely.
+func parsePodSecurity(annotations map[string]string) ([]kyvernov1.PodSecurityStandard, error) {
+	value, ok := annotations[PodSecurityAnnotation]
+	if !ok {
+		return nil, nil
+	}
+
+	var podSecurity []kyvernov1.PodSecurityStandard
+	if err := json.Unmarshal([]byte(value), &podSecurity); err != nil {
+		return nil, fmt.Errorf("invalid %s annotation: %w", PodSecurityAnnotation, err)
+	}
+
+	if len(podSecurity) == 0 {
+		return nil, fmt.Errorf("invalid %s annotation: at least one control is required", PodSecurityAnnotation)
+	}
+
+	for i, control := range podSecurity {
+		if _, known := pssutils.PSS_control_name_to_ids[control.ControlName]; !known {
+			return nil, fmt.Errorf("invalid %s annotation: control %d has unknown controlName %q", PodSecurityAnnotation, i, control.ControlName)
+		}
+		if errs := control.Validate(field.NewPath("podSecurity").Index(i)); len(errs) != 0 {
+			return nil, fmt.Errorf("invalid %s annotation: %w", PodSecurityAnnotation, errs.ToAggregate())
+		}
+	}
+
+	return podSecurity, nil
+}
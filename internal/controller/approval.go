@@ -0,0 +1,11 @@
+package controller
+
+import (
+	policyAPI "github.com/giantswarm/policy-api/api/v1alpha1"
+)
+
+// SpecHash returns the hash an approver binds their approval to.
+// It covers the spec and every annotation changing the translation, so widening an approved exception requires a new approval.
+func SpecHash(gsPolicyException *policyAPI.PolicyException) (string, error) {
+	return sourceHash(gsPolicyException.Spec, gsPolicyException.Annotations)
+}
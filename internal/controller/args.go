@@ -0,0 +1,213 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	policyAPI "github.com/giantswarm/policy-api/api/v1alpha1"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// ArgsContextName is the Kyverno context variable holding the PolicyManifest args,
+// e.g. {{ policyArgs.data."allowed-registries" }}.
+const ArgsContextName = "policyArgs"
+
+// AppliedArgsAnnotation records the <namespace>/<name> of the args ConfigMap added to the context of a ClusterPolicy,
+// so context entries defined by the policy authors are never removed.
+const AppliedArgsAnnotation = "policy.giantswarm.io/applied-args"
+
+// parseArgs returns the PolicyManifest args as ConfigMap data.
+// Args are --key=value or key=value pairs, flags without a value are set to "true".
+func parseArgs(args []string) (map[string]string, error) {
+	data := make(map[string]string, len(args))
+	for i, arg := range args {
+		key, value, found := strings.Cut(strings.TrimPrefix(arg, "--"), "=")
+		if !found {
+			value = "true"
+		}
+		if errs := validation.IsConfigMapKey(key); len(errs) != 0 {
+			return nil, fmt.Errorf("invalid args[%d] %q: %s", i, arg, strings.Join(errs, ", "))
+		}
+		if _, duplicate := data[key]; duplicate {
+			return nil, fmt.Errorf("invalid args[%d] %q: duplicate key %s", i, arg, key)
+		}
+		data[key] = value
+	}
+
+	return data, nil
+}
+
+// argsConfigMapName returns the name of the ConfigMap holding the args of a PolicyManifest.
+func argsConfigMapName(polman *policyAPI.PolicyManifest) string {
+	return fmt.Sprintf("gs-kpo-%s-args", polman.Name)
+}
+
+// reconcileArgs writes the args of the PolicyManifest to a managed ConfigMap and adds it as a context entry to every rule of the ClusterPolicy.
+// Without args the ConfigMap and the context entries are removed, if the AppliedArgsAnnotation shows the operator added them.
+// Like the mode, context entries removed by another manager after they were applied aren't added again, otherwise both would keep
+// reverting each other. It returns whether the ClusterPolicy drifted, which is reported once, unless driftReported.
+func (r *PolicyManifestReconciler) reconcileArgs(ctx context.Context, polman *policyAPI.PolicyManifest, data map[string]string, driftReported bool) (bool, error) {
+	configMap := corev1.ConfigMap{}
+	configMap.Namespace = r.DestinationNamespace
+	configMap.Name = argsConfigMapName(polman)
+
+	if r.DryRun != nil {
+		log.Log.Info(fmt.Sprintf("Dry-run: would write %d args of %s to ConfigMap %s/%s", len(data), polman.Name, configMap.Namespace, configMap.Name))
+		return false, nil
+	}
+
+	var clusterPolicy kyvernov1.ClusterPolicy
+	found := true
+	if err := r.Get(ctx, types.NamespacedName{Name: polman.Name}, &clusterPolicy); err != nil {
+		// Missing policies are reported by the translation
+		if !errors.IsNotFound(err) {
+			return false, err
+		}
+		found = false
+	}
+
+	// PolicyManifests which never had args leave the ClusterPolicy and the ConfigMaps alone
+	applied := clusterPolicy.Annotations[AppliedArgsAnnotation]
+	if len(data) == 0 && applied == "" {
+		return false, nil
+	}
+
+	// The ConfigMap left in a previous destination namespace is replaced
+	if applied != "" && applied != client.ObjectKeyFromObject(&configMap).String() {
+		previous := corev1.ConfigMap{}
+		previous.Namespace, previous.Name, _ = strings.Cut(applied, "/")
+		if err := client.IgnoreNotFound(r.Delete(ctx, &previous)); err != nil {
+			return false, err
+		}
+	}
+
+	if len(data) == 0 {
+		if err := client.IgnoreNotFound(r.Delete(ctx, &configMap)); err != nil {
+			return false, err
+		}
+	} else if op, err := controllerutil.CreateOrUpdate(ctx, r.Client, &configMap, func() error {
+		if configMap.Labels == nil {
+			configMap.Labels = make(map[string]string)
+		}
+		maps.Copy(configMap.Labels, generateLabels())
+		configMap.Labels[GSPolicy] = polman.Name
+		configMap.Data = data
+
+		return controllerutil.SetControllerReference(polman, &configMap, r.Scheme)
+	}); err != nil {
+		return false, err
+	} else if op != controllerutil.OperationResultNone {
+		log.Log.Info(fmt.Sprintf("ConfigMap %s: %s", configMap.Name, op))
+		r.Recorder.Eventf(polman, &configMap, corev1.EventTypeNormal, ReasonArgsApplied, ActionTranslate,
+			"ConfigMap %s/%s %s with %d args", configMap.Namespace, configMap.Name, op, len(data))
+	}
+
+	if !found {
+		return false, nil
+	}
+
+	if len(data) != 0 && applied == client.ObjectKeyFromObject(&configMap).String() && isArgsContextReverted(&clusterPolicy) {
+		if !driftReported {
+			log.Log.Info(fmt.Sprintf("ClusterPolicy %s drifted from the args of %s", clusterPolicy.Name, polman.Name))
+			r.Recorder.Eventf(polman, &clusterPolicy, corev1.EventTypeWarning, ReasonArgsDrifted, ActionTranslate,
+				"ClusterPolicy %s was changed by another manager, the %s context is not added again", clusterPolicy.Name, ArgsContextName)
+			r.Recorder.Eventf(&clusterPolicy, polman, corev1.EventTypeWarning, ReasonArgsDrifted, ActionTranslate,
+				"The %s context was removed, the args of PolicyManifest %s are not added again", ArgsContextName, polman.Name)
+		}
+		return true, nil
+	}
+
+	// The whole rule list is patched, so concurrent changes to the ClusterPolicy make the patch fail instead of being lost
+	patch := client.MergeFromWithOptions(clusterPolicy.DeepCopy(), client.MergeFromWithOptimisticLock{})
+	changed, conflicts := setArgsContext(&clusterPolicy, &configMap, len(data) != 0)
+	if len(conflicts) > 0 {
+		log.Log.Info(fmt.Sprintf("ClusterPolicy %s: %s context of rules %s defined by the policy", clusterPolicy.Name, ArgsContextName, strings.Join(conflicts, ", ")))
+		r.Recorder.Eventf(polman, &clusterPolicy, corev1.EventTypeWarning, ReasonArgsConflict, ActionTranslate,
+			"Rules %s of ClusterPolicy %s already define the %s context, their args are left unchanged", strings.Join(conflicts, ", "), clusterPolicy.Name, ArgsContextName)
+	}
+	if !changed {
+		return false, nil
+	}
+	if err := r.Patch(ctx, &clusterPolicy, patch); err != nil {
+		return false, err
+	}
+
+	log.Log.Info(fmt.Sprintf("ClusterPolicy %s: %s context updated", clusterPolicy.Name, ArgsContextName))
+	return false, nil
+}
+
+// setArgsContext adds the context entry of the args ConfigMap to every rule, or removes the entries added before, and reports whether the ClusterPolicy changed.
+// The ConfigMap is recorded in the AppliedArgsAnnotation, entries referencing other ConfigMaps are only replaced or removed if they were added by the operator.
+// It returns the names of the rules left alone because their policy authors defined the context entry.
+func setArgsContext(policy *kyvernov1.ClusterPolicy, configMap *corev1.ConfigMap, present bool) (bool, []string) {
+	entry := kyvernov1.ContextEntry{
+		Name:      ArgsContextName,
+		ConfigMap: &kyvernov1.ConfigMapReference{Name: configMap.Name, Namespace: configMap.Namespace},
+	}
+
+	applied := policy.Annotations[AppliedArgsAnnotation]
+	appliedEntry := kyvernov1.ContextEntry{Name: ArgsContextName, ConfigMap: &kyvernov1.ConfigMapReference{}}
+	appliedEntry.ConfigMap.Namespace, appliedEntry.ConfigMap.Name, _ = strings.Cut(applied, "/")
+
+	changed := false
+	var conflicts []string
+	for i := range policy.Spec.Rules {
+		rule := &policy.Spec.Rules[i]
+		index := slices.IndexFunc(rule.Context, func(entry kyvernov1.ContextEntry) bool {
+			return entry.Name == ArgsContextName
+		})
+
+		switch {
+		case !present && index >= 0 && applied != "" && isArgsContextEntry(rule.Context[index], &appliedEntry):
+			rule.Context = slices.Delete(rule.Context, index, index+1)
+			changed = true
+		case !present || (index >= 0 && isArgsContextEntry(rule.Context[index], &entry)):
+			// Nothing was added, or the entry is up to date
+		case index < 0:
+			// Other context entries may reference the args, so they come first
+			rule.Context = slices.Insert(rule.Context, 0, entry)
+			changed = true
+		case applied != "" && isArgsContextEntry(rule.Context[index], &appliedEntry):
+			// The ConfigMap moved, e.g. to a new destination namespace
+			rule.Context[index] = entry
+			changed = true
+		default:
+			conflicts = append(conflicts, rule.Name)
+		}
+	}
+
+	switch {
+	case present && applied != client.ObjectKeyFromObject(configMap).String():
+		metav1.SetMetaDataAnnotation(&policy.ObjectMeta, AppliedArgsAnnotation, client.ObjectKeyFromObject(configMap).String())
+		changed = true
+	case !present && applied != "":
+		delete(policy.Annotations, AppliedArgsAnnotation)
+		changed = true
+	}
+
+	return changed, conflicts
+}
+
+// isArgsContextReverted reports whether a rule lacks the args context entry, after the operator added it to every rule.
+func isArgsContextReverted(policy *kyvernov1.ClusterPolicy) bool {
+	return slices.ContainsFunc(policy.Spec.Rules, func(rule kyvernov1.Rule) bool {
+		return !slices.ContainsFunc(rule.Context, func(entry kyvernov1.ContextEntry) bool { return entry.Name == ArgsContextName })
+	})
+}
+
+// isArgsContextEntry reports whether entry only references the same ConfigMap as want.
+func isArgsContextEntry(entry kyvernov1.ContextEntry, want *kyvernov1.ContextEntry) bool {
+	return entry.ConfigMap != nil && *entry.ConfigMap == *want.ConfigMap &&
+		entry.APICall == nil && entry.ImageRegistry == nil && entry.Variable == nil && entry.GlobalReference == nil
+}
@@ -19,20 +19,27 @@ package controller
 import (
 	"context"
 	"fmt"
+	"maps"
 
 	kyvernov2 "github.com/kyverno/kyverno/api/kyverno/v2"
 	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 
 	"github.com/go-logr/logr"
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/events"
 
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
+	"github.com/giantswarm/kyverno-policy-operator/internal/metrics"
+	"github.com/giantswarm/kyverno-policy-operator/internal/policycache"
 	"github.com/giantswarm/kyverno-policy-operator/internal/utils"
 )
 
@@ -43,13 +50,19 @@ type ClusterPolicyReconciler struct {
 	Log                         logr.Logger
 	ExceptionList               map[string]kyvernov1.ClusterPolicy
 	ChartOperatorExceptionKinds []string
-	PolicyCache                 map[string]kyvernov1.ClusterPolicy
+	PolicyCache                 *policycache.Cache
 	MaxJitterPercent            int
+	Recorder                    events.EventRecorder
+	// PolicyUpdates receives every ClusterPolicy change so dependent controllers reconcile right away.
+	PolicyUpdates []chan<- event.GenericEvent
+	// DryRun records the changes to the chart-operator Kyverno PolicyException instead of applying them.
+	DryRun *DryRun
 }
 
 //+kubebuilder:rbac:groups=kyverno.io,resources=clusterpolicies,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=kyverno.io,resources=clusterpolicies/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=kyverno.io,resources=clusterpolicies/finalizers,verbs=update
+//+kubebuilder:rbac:groups=events.k8s.io,resources=events,verbs=create;patch
 
 func (r *ClusterPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	_ = log.FromContext(ctx)
@@ -62,7 +75,10 @@ func (r *ClusterPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 
 		// Check if the ClusterPolicy was deleted
 		if errors.IsNotFound(err) {
-			// Ignore
+			if _, cached := r.PolicyCache.Get(req.Name); cached {
+				metrics.PolicyCacheSize.Set(float64(r.PolicyCache.Delete(req.Name)))
+				notifyDependents(ctx, r.PolicyUpdates, &kyvernov1.ClusterPolicy{ObjectMeta: metav1.ObjectMeta{Name: req.Name}})
+			}
 			return ctrl.Result{}, nil
 		}
 
@@ -71,12 +87,20 @@ func (r *ClusterPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 
 	}
 
+	cachedPolicy, cached := r.PolicyCache.Get(clusterPolicy.Name)
+	var size int
 	if !clusterPolicy.DeletionTimestamp.IsZero() {
-		delete(r.PolicyCache, clusterPolicy.Name)
+		size = r.PolicyCache.Delete(clusterPolicy.Name)
 	} else {
-		r.PolicyCache[clusterPolicy.Name] = clusterPolicy
+		size = r.PolicyCache.Set(clusterPolicy.Name, &clusterPolicy)
 		r.Log.Info(fmt.Sprintf("Updated cached ClusterPolicy %s", clusterPolicy.Name))
 	}
+	metrics.PolicyCacheSize.Set(float64(size))
+
+	// Only notify dependents on changes, periodic resyncs are handled by their own requeue
+	if !cached || cachedPolicy.GetResourceVersion() != clusterPolicy.ResourceVersion || !clusterPolicy.DeletionTimestamp.IsZero() {
+		notifyDependents(ctx, r.PolicyUpdates, &clusterPolicy)
+	}
 
 	if len(r.ChartOperatorExceptionKinds) != 0 {
 		// Check if the Policy has validate rules
@@ -114,10 +138,13 @@ func (r *ClusterPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 							// Set Spec.Match.All
 							policyException.Spec.Match.All = templateResourceFilters(r.ChartOperatorExceptionKinds)
 
-							policies := []kyvernov1.ClusterPolicy{clusterPolicy}
+							policies := []kyvernov1.PolicyInterface{&clusterPolicy}
 
 							// Set .Spec.Exceptions
-							newExceptions := translatePoliciesToExceptions(policies)
+							newExceptions, err := translatePoliciesToExceptions(policies, nil, false)
+							if err != nil {
+								return ctrl.Result{}, err
+							}
 							policyException.Spec.Exceptions = newExceptions
 
 							// Patch PolicyException Kinds
@@ -131,8 +158,14 @@ func (r *ClusterPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 
 							if err := r.CreateOrUpdate(ctx, &policyException); err != nil {
 								log.Log.Error(err, "Error creating PolicyException")
+								r.Recorder.Eventf(&clusterPolicy, &policyException, corev1.EventTypeWarning, ReasonKyvernoRejected, ActionTranslate,
+									"Kyverno PolicyException %s was rejected: %s", client.ObjectKeyFromObject(&policyException), err)
+								metrics.ChartOperatorBypassUpdates.WithLabelValues(metrics.ControllerClusterPolicy, metrics.ResultFailure).Inc()
 							} else {
 								log.Log.Info(fmt.Sprintf("ClusterPolicy %s triggered a PolicyException update: %s", clusterPolicy.Name, client.ObjectKeyFromObject(&policyException)))
+								r.Recorder.Eventf(&clusterPolicy, &policyException, corev1.EventTypeNormal, ReasonUpdated, ActionTranslate,
+									"ClusterPolicy triggered a chart-operator bypass update: %s", client.ObjectKeyFromObject(&policyException))
+								metrics.ChartOperatorBypassUpdates.WithLabelValues(metrics.ControllerClusterPolicy, metrics.ResultSuccess).Inc()
 							}
 
 							return ctrl.Result{}, nil
@@ -148,7 +181,21 @@ func (r *ClusterPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 
 // CreateOrUpdate attempts first to patch the object given but if an IsNotFound error
 // is returned it instead creates the resource.
+// In dry-run mode the merge patch is only recorded.
 func (r *ClusterPolicyReconciler) CreateOrUpdate(ctx context.Context, obj client.Object) error {
+	if policyException, ok := obj.(*kyvernov2.PolicyException); ok && r.DryRun != nil {
+		desired := policyException.DeepCopy()
+		_, err := createOrUpdatePolicyException(ctx, r.Client, r.DryRun, dryRunSource(ClusterPolicyKind, client.ObjectKeyFromObject(obj)), policyException, func() error {
+			if policyException.Labels == nil {
+				policyException.Labels = make(map[string]string)
+			}
+			maps.Copy(policyException.Labels, desired.Labels)
+			policyException.Spec = desired.Spec
+			return nil
+		})
+		return err
+	}
+
 	existingObj := unstructured.Unstructured{}
 	existingObj.SetGroupVersionKind(obj.GetObjectKind().GroupVersionKind())
 
@@ -0,0 +1,59 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+
+	kyvernov2 "github.com/kyverno/kyverno/api/kyverno/v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/events"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/giantswarm/kyverno-policy-operator/internal/metrics"
+)
+
+// errBroadDelete is returned when a deletion isn't scoped to the Kyverno PolicyExceptions of a single source.
+var errBroadDelete = errors.New("refusing to delete Kyverno PolicyExceptions not owned by the source")
+
+// ownedSelector returns the labels selecting the Kyverno PolicyExceptions generated from source.
+// Sources without a UID are refused, the empty label value would match the exceptions of other sources.
+func ownedSelector(source client.Object) (client.MatchingLabels, error) {
+	if source.GetUID() == "" {
+		return nil, fmt.Errorf("%w: %s has no UID", errBroadDelete, source.GetName())
+	}
+
+	return client.MatchingLabels{
+		ManagedBy: ComponentName,
+		SourceUID: string(source.GetUID()),
+	}, nil
+}
+
+// checkOwned returns errBroadDelete unless policyException was generated from source, or is the legacy PolicyException named legacyName.
+func checkOwned(policyException *kyvernov2.PolicyException, source client.Object, legacyName string) error {
+	if policyException.Labels[ManagedBy] != ComponentName {
+		return fmt.Errorf("%w: %s is not managed by %s", errBroadDelete, client.ObjectKeyFromObject(policyException), ComponentName)
+	}
+
+	switch policyException.Labels[SourceUID] {
+	case string(source.GetUID()):
+		return nil
+	case "":
+		if policyException.Name == legacyName {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: %s was generated from another source", errBroadDelete, client.ObjectKeyFromObject(policyException))
+}
+
+// reportBroadDelete emits a Warning event and counts the refusal when the deletion guard fired.
+func reportBroadDelete(recorder events.EventRecorder, source client.Object, controller string, err error) {
+	if !errors.Is(err, errBroadDelete) {
+		return
+	}
+
+	log.Log.Error(err, fmt.Sprintf("deletion guard fired for %s", source.GetName()))
+	recorder.Eventf(source, nil, corev1.EventTypeWarning, ReasonBroadDeleteRefused, ActionDelete, "%s", err)
+	metrics.BroadDeletesRefused.WithLabelValues(controller).Inc()
+}
@@ -0,0 +1,89 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	policyAPI "github.com/giantswarm/policy-api/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// Field indexes used to find the objects depending on a Kyverno policy.
+const (
+	// PolicyExceptionPoliciesField indexes Giant Swarm PolicyExceptions by the policies they reference.
+	PolicyExceptionPoliciesField = "spec.policies"
+	// PolicyManifestPolicyField indexes PolicyManifests by the ClusterPolicy they describe.
+	PolicyManifestPolicyField = "policy"
+)
+
+// notifyDependents sends the policy to every dependent controller so objects referencing it are reconciled right away.
+// It is called after the PolicyCache was updated, so the dependents always read the new policy.
+func notifyDependents(ctx context.Context, dependents []chan<- event.GenericEvent, policy client.Object) {
+	for _, dependent := range dependents {
+		select {
+		case dependent <- event.GenericEvent{Object: policy}:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// policyKeyForObject returns the PolicyCache key of a Kyverno policy received in a GenericEvent.
+func policyKeyForObject(obj client.Object) string {
+	if obj.GetNamespace() != "" {
+		return types.NamespacedName{Namespace: obj.GetNamespace(), Name: obj.GetName()}.String()
+	}
+
+	return obj.GetName()
+}
+
+// indexPolicyExceptionPolicies registers the PolicyExceptionPoliciesField index.
+func indexPolicyExceptionPolicies(ctx context.Context, mgr ctrl.Manager) error {
+	return mgr.GetFieldIndexer().IndexField(ctx, &policyAPI.PolicyException{}, PolicyExceptionPoliciesField, func(obj client.Object) []string {
+		return obj.(*policyAPI.PolicyException).Spec.Policies
+	})
+}
+
+// indexPolicyManifestPolicy registers the PolicyManifestPolicyField index.
+func indexPolicyManifestPolicy(ctx context.Context, mgr ctrl.Manager) error {
+	return mgr.GetFieldIndexer().IndexField(ctx, &policyAPI.PolicyManifest{}, PolicyManifestPolicyField, func(obj client.Object) []string {
+		return []string{obj.GetName()}
+	})
+}
+
+// mapPolicyToExceptions enqueues the Giant Swarm PolicyExceptions referencing the policy.
+func (r *PolicyExceptionReconciler) mapPolicyToExceptions(ctx context.Context, obj client.Object) []reconcile.Request {
+	var gsPolicyExceptions policyAPI.PolicyExceptionList
+	if err := r.List(ctx, &gsPolicyExceptions, client.MatchingFields{PolicyExceptionPoliciesField: policyKeyForObject(obj)}); err != nil {
+		log.Log.Error(err, fmt.Sprintf("unable to list PolicyExceptions referencing %s", policyKeyForObject(obj)))
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0, len(gsPolicyExceptions.Items))
+	for _, gsPolicyException := range gsPolicyExceptions.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&gsPolicyException)})
+	}
+
+	return requests
+}
+
+// mapPolicyToManifests enqueues the PolicyManifest describing the policy.
+func (r *PolicyManifestReconciler) mapPolicyToManifests(ctx context.Context, obj client.Object) []reconcile.Request {
+	var polmans policyAPI.PolicyManifestList
+	if err := r.List(ctx, &polmans, client.MatchingFields{PolicyManifestPolicyField: policyKeyForObject(obj)}); err != nil {
+		log.Log.Error(err, fmt.Sprintf("unable to list PolicyManifests for %s", policyKeyForObject(obj)))
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0, len(polmans.Items))
+	for _, polman := range polmans.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&polman)})
+	}
+
+	return requests
+}
@@ -0,0 +1,93 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	policyAPI "github.com/giantswarm/policy-api/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// DestinationRule maps Giant Swarm PolicyExceptions to the namespace their Kyverno PolicyException is created in.
+// A rule matches the source namespaces listed in Namespaces, or whose labels match NamespaceSelector.
+type DestinationRule struct {
+	Namespaces        []string              `json:"namespaces,omitempty"`
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+	Destination       string                `json:"destination"`
+}
+
+// ValidateDestinationRules checks that every rule has a valid destination and matches at least one namespace.
+func ValidateDestinationRules(rules []DestinationRule) error {
+	for i, rule := range rules {
+		if errs := validation.IsDNS1123Label(rule.Destination); len(errs) != 0 {
+			return fmt.Errorf("destination rule %d: invalid destination %q: %v", i, rule.Destination, errs)
+		}
+		if len(rule.Namespaces) == 0 && rule.NamespaceSelector == nil {
+			return fmt.Errorf("destination rule %d: namespaces or a namespaceSelector are required", i)
+		}
+		if _, err := metav1.LabelSelectorAsSelector(rule.NamespaceSelector); err != nil {
+			return fmt.Errorf("destination rule %d: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+// hasNamespaceSelectors reports whether the rules depend on namespace labels.
+func hasNamespaceSelectors(rules []DestinationRule) bool {
+	return slices.ContainsFunc(rules, func(rule DestinationRule) bool {
+		return rule.NamespaceSelector != nil
+	})
+}
+
+// destinationNamespace returns the destination of the first rule matching the namespace of gsPolicyException, or fallback.
+func (r *PolicyExceptionReconciler) destinationNamespace(ctx context.Context, gsPolicyException *policyAPI.PolicyException, fallback string) (string, error) {
+	var sourceNamespace *corev1.Namespace
+	for _, rule := range r.DestinationRules {
+		if slices.Contains(rule.Namespaces, gsPolicyException.Namespace) {
+			return rule.Destination, nil
+		}
+		if rule.NamespaceSelector == nil {
+			continue
+		}
+
+		// The namespace labels are only fetched once a rule needs them
+		if sourceNamespace == nil {
+			sourceNamespace = &corev1.Namespace{}
+			if err := r.Get(ctx, types.NamespacedName{Name: gsPolicyException.Namespace}, sourceNamespace); err != nil {
+				return "", err
+			}
+		}
+		selector, err := metav1.LabelSelectorAsSelector(rule.NamespaceSelector)
+		if err != nil {
+			return "", err
+		}
+		if selector.Matches(labels.Set(sourceNamespace.Labels)) {
+			return rule.Destination, nil
+		}
+	}
+
+	return fallback, nil
+}
+
+// mapNamespaceToExceptions enqueues the Giant Swarm PolicyExceptions of a namespace whose labels may have changed their destination.
+func (r *PolicyExceptionReconciler) mapNamespaceToExceptions(ctx context.Context, obj client.Object) []reconcile.Request {
+	var gsPolicyExceptions policyAPI.PolicyExceptionList
+	if err := r.List(ctx, &gsPolicyExceptions, client.InNamespace(obj.GetName())); err != nil {
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0, len(gsPolicyExceptions.Items))
+	for _, gsPolicyException := range gsPolicyExceptions.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&gsPolicyException)})
+	}
+
+	return requests
+}
@@ -0,0 +1,224 @@
+package controller
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"sync"
+
+	kyvernov2 "github.com/kyverno/kyverno/api/kyverno/v2"
+	"gomodules.xyz/jsonpatch/v2"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/giantswarm/kyverno-policy-operator/internal/metrics"
+)
+
+// Operations a dry-run would apply to a Kyverno PolicyException.
+const (
+	DryRunCreate = "create"
+	DryRunUpdate = "update"
+	DryRunDelete = "delete"
+)
+
+// PolicyExceptionDiff describes the change a dry-run would apply to a Kyverno PolicyException.
+type PolicyExceptionDiff struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Operation string `json:"operation"`
+	// Source is the kind and key of the object the Kyverno PolicyException is translated from.
+	Source string `json:"source"`
+	// Patch is the JSON patch from the labels, annotations, owner references and spec in the cluster to the desired ones.
+	Patch []jsonpatch.Operation `json:"patch,omitempty"`
+}
+
+// DryRun records the changes the reconcilers would apply to Kyverno PolicyExceptions instead of applying them.
+// The pending changes are logged, served as JSON and counted in the kyverno_policy_operator_dry_run_pending_changes metric.
+type DryRun struct {
+	mu    sync.RWMutex
+	diffs map[types.NamespacedName]PolicyExceptionDiff
+}
+
+// NewDryRun returns an empty DryRun.
+func NewDryRun() *DryRun {
+	return &DryRun{diffs: make(map[types.NamespacedName]PolicyExceptionDiff)}
+}
+
+// Diffs returns the pending changes sorted by namespace and name.
+func (d *DryRun) Diffs() []PolicyExceptionDiff {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	diffs := make([]PolicyExceptionDiff, 0, len(d.diffs))
+	for _, diff := range d.diffs {
+		diffs = append(diffs, diff)
+	}
+	slices.SortFunc(diffs, func(a, b PolicyExceptionDiff) int {
+		return cmp.Or(cmp.Compare(a.Namespace, b.Namespace), cmp.Compare(a.Name, b.Name))
+	})
+
+	return diffs
+}
+
+// ServeHTTP serves the pending changes as a JSON list.
+func (d *DryRun) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(d.Diffs()); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// record stores diff as the pending change of its Kyverno PolicyException.
+func (d *DryRun) record(diff PolicyExceptionDiff) {
+	patch, _ := json.Marshal(diff.Patch)
+	log.Log.Info(fmt.Sprintf("Dry-run: would %s PolicyException %s/%s", diff.Operation, diff.Namespace, diff.Name),
+		"source", diff.Source, "patch", string(patch))
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.diffs[types.NamespacedName{Namespace: diff.Namespace, Name: diff.Name}] = diff
+	d.updateMetric()
+}
+
+// forget drops the pending change of a Kyverno PolicyException which is up to date.
+func (d *DryRun) forget(key types.NamespacedName) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	delete(d.diffs, key)
+	d.updateMetric()
+}
+
+// forgetSource drops the pending changes recorded for source, so they are replaced by the ones planned next.
+func (d *DryRun) forgetSource(source string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	maps.DeleteFunc(d.diffs, func(_ types.NamespacedName, diff PolicyExceptionDiff) bool {
+		return diff.Source == source
+	})
+	d.updateMetric()
+}
+
+// updateMetric counts the pending changes by operation. Callers hold the lock.
+func (d *DryRun) updateMetric() {
+	counts := map[string]int{DryRunCreate: 0, DryRunUpdate: 0, DryRunDelete: 0}
+	for _, diff := range d.diffs {
+		counts[diff.Operation]++
+	}
+	for operation, count := range counts {
+		metrics.DryRunPendingChanges.WithLabelValues(operation).Set(float64(count))
+	}
+}
+
+// createOrUpdatePolicyException works like controllerutil.CreateOrUpdate, but only records the change when dryRun is set.
+func createOrUpdatePolicyException(ctx context.Context, c client.Client, dryRun *DryRun, source string, policyException *kyvernov2.PolicyException, mutate controllerutil.MutateFn) (controllerutil.OperationResult, error) {
+	if dryRun == nil {
+		return controllerutil.CreateOrUpdate(ctx, c, policyException, mutate)
+	}
+
+	key := client.ObjectKeyFromObject(policyException)
+	operation := DryRunUpdate
+	var existing *kyvernov2.PolicyException
+	if err := c.Get(ctx, key, policyException); err != nil {
+		if !errors.IsNotFound(err) {
+			return controllerutil.OperationResultNone, err
+		}
+		operation = DryRunCreate
+	} else {
+		existing = policyException.DeepCopy()
+	}
+
+	if err := mutate(); err != nil {
+		return controllerutil.OperationResultNone, err
+	}
+
+	if existing != nil && equality.Semantic.DeepEqual(existing, policyException) {
+		dryRun.forget(key)
+		return controllerutil.OperationResultNone, nil
+	}
+
+	patch, err := diffPolicyExceptions(existing, policyException)
+	if err != nil {
+		return controllerutil.OperationResultNone, err
+	}
+	dryRun.record(PolicyExceptionDiff{
+		Namespace: key.Namespace,
+		Name:      key.Name,
+		Operation: operation,
+		Source:    source,
+		Patch:     patch,
+	})
+
+	if operation == DryRunCreate {
+		return controllerutil.OperationResultCreated, nil
+	}
+	return controllerutil.OperationResultUpdated, nil
+}
+
+// deletePolicyException deletes a Kyverno PolicyException, or only records the deletion when dryRun is set.
+func deletePolicyException(ctx context.Context, c client.Client, dryRun *DryRun, source string, policyException *kyvernov2.PolicyException) error {
+	if dryRun == nil {
+		return client.IgnoreNotFound(c.Delete(ctx, policyException))
+	}
+
+	dryRun.record(PolicyExceptionDiff{
+		Namespace: policyException.Namespace,
+		Name:      policyException.Name,
+		Operation: DryRunDelete,
+		Source:    source,
+	})
+
+	return nil
+}
+
+// diffPolicyExceptions returns the JSON patch between the parts of two Kyverno PolicyExceptions managed by the operator.
+// A nil existing PolicyException is compared as an empty object.
+func diffPolicyExceptions(existing, desired *kyvernov2.PolicyException) ([]jsonpatch.Operation, error) {
+	view := func(policyException *kyvernov2.PolicyException) ([]byte, error) {
+		if policyException == nil {
+			return []byte("{}"), nil
+		}
+		return json.Marshal(map[string]any{
+			"metadata": map[string]any{
+				"labels":          policyException.Labels,
+				"annotations":     policyException.Annotations,
+				"ownerReferences": policyException.OwnerReferences,
+			},
+			"spec": policyException.Spec,
+		})
+	}
+
+	existingJSON, err := view(existing)
+	if err != nil {
+		return nil, err
+	}
+	desiredJSON, err := view(desired)
+	if err != nil {
+		return nil, err
+	}
+
+	return jsonpatch.CreatePatch(existingJSON, desiredJSON)
+}
+
+// dryRunSource formats the key of the object a Kyverno PolicyException is translated from for PolicyExceptionDiff.Source.
+func dryRunSource(kind string, source types.NamespacedName) string {
+	return fmt.Sprintf("%s %s", kind, source)
+}
+
+// DiscardEventRecorder drops every event. It is used in dry-run mode, where nothing the events describe actually happens.
+type DiscardEventRecorder struct{}
+
+// Eventf implements events.EventRecorder.
+func (DiscardEventRecorder) Eventf(regarding runtime.Object, related runtime.Object, eventtype, reason, action, note string, args ...interface{}) {
+}
@@ -0,0 +1,32 @@
+package controller
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/events"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// Actions reported in the events emitted by the reconcilers.
+const (
+	ActionTranslate = "Translate"
+	ActionDelete    = "Delete"
+)
+
+// recordOperationEvent emits a Normal event on obj when the generated Kyverno PolicyException was created or updated.
+// Unchanged objects are not reported to avoid flooding the event stream on every resync.
+func recordOperationEvent(recorder events.EventRecorder, obj runtime.Object, policyException client.Object, op controllerutil.OperationResult) {
+	var reason string
+	switch op {
+	case controllerutil.OperationResultCreated:
+		reason = ReasonCreated
+	case controllerutil.OperationResultUpdated:
+		reason = ReasonUpdated
+	default:
+		return
+	}
+
+	recorder.Eventf(obj, policyException, corev1.EventTypeNormal, reason, ActionTranslate,
+		"Kyverno PolicyException %s/%s %s", policyException.GetNamespace(), policyException.GetName(), op)
+}
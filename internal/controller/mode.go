@@ -0,0 +1,163 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	policyAPI "github.com/giantswarm/policy-api/api/v1alpha1"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/giantswarm/kyverno-policy-operator/internal/metrics"
+)
+
+// Values of the PolicyManifest spec.mode field. An empty mode leaves the ClusterPolicy untouched.
+const (
+	ModeEnforce = "enforce"
+	ModeAudit   = "audit"
+	// ModeWarming audits violations ahead of enforcing the policy. It sets spec.emitWarning, so Kyverno
+	// returns the violations as warnings in the admission response shown to the client, e.g. by kubectl.
+	// Applying it is reported with Warming events on the PolicyManifest and the ClusterPolicy.
+	ModeWarming = "warming"
+)
+
+// AppliedModeAnnotation records the PolicyManifest mode last applied to a ClusterPolicy,
+// so edits made by hand can be told apart from mode changes.
+const AppliedModeAnnotation = "policy.giantswarm.io/applied-mode"
+
+// ActionEnforce is reported in the events about the enforcement of ClusterPolicies.
+const ActionEnforce = "Enforce"
+
+// enforcement is the part of a ClusterPolicy driven by the PolicyManifest mode.
+type enforcement struct {
+	action      kyvernov1.ValidationFailureAction
+	emitWarning bool
+}
+
+// parseMode returns the enforcement of a PolicyManifest mode, or nil for an empty mode.
+func parseMode(mode string) (*enforcement, error) {
+	switch mode {
+	case "":
+		return nil, nil
+	case ModeEnforce:
+		return &enforcement{action: kyvernov1.Enforce}, nil
+	case ModeAudit:
+		return &enforcement{action: kyvernov1.Audit}, nil
+	case ModeWarming:
+		return &enforcement{action: kyvernov1.Audit, emitWarning: true}, nil
+	default:
+		return nil, fmt.Errorf("invalid mode %q: must be one of %s, %s or %s", mode, ModeEnforce, ModeAudit, ModeWarming)
+	}
+}
+
+// enforces reports whether the policy and the failure actions of its validate rules already match e.
+func (e enforcement) enforces(policy *kyvernov1.ClusterPolicy) bool {
+	// The deprecated lowercase actions are still accepted by Kyverno
+	if policy.Spec.ValidationFailureAction.Enforce() != e.action.Enforce() {
+		return false
+	}
+	for _, rule := range policy.Spec.Rules {
+		if rule.Validation != nil && rule.Validation.FailureAction != nil && rule.Validation.FailureAction.Enforce() != e.action.Enforce() {
+			return false
+		}
+	}
+
+	emitWarning := policy.Spec.EmitWarning != nil && *policy.Spec.EmitWarning
+	return emitWarning == e.emitWarning
+}
+
+// apply sets the failure action of the policy and of the validate rules overriding it.
+// Namespace-wise failure action overrides are left untouched.
+func (e enforcement) apply(policy *kyvernov1.ClusterPolicy) {
+	policy.Spec.ValidationFailureAction = e.action
+	for i := range policy.Spec.Rules {
+		if validation := policy.Spec.Rules[i].Validation; validation != nil && validation.FailureAction != nil {
+			action := e.action
+			validation.FailureAction = &action
+		}
+	}
+
+	if e.emitWarning {
+		emitWarning := true
+		policy.Spec.EmitWarning = &emitWarning
+	} else {
+		policy.Spec.EmitWarning = nil
+	}
+}
+
+// modeState is the outcome of applying a PolicyManifest mode to its ClusterPolicy.
+type modeState int
+
+const (
+	// modePending is reported while the ClusterPolicy doesn't exist, or in dry-run mode.
+	modePending modeState = iota
+	// modeApplied is reported once the ClusterPolicy is set to the mode.
+	modeApplied
+	// modeDrifted is reported when another manager changed the enforcement after the mode was applied.
+	modeDrifted
+)
+
+// reconcileMode applies the PolicyManifest mode to its ClusterPolicy.
+// ClusterPolicies are usually owned by Helm or GitOps tooling, so once their enforcement is changed after the mode was applied,
+// the mode isn't re-applied, otherwise both would keep reverting each other. The drift is reported once, unless driftReported.
+// The mode is applied again once it changes, or once the AppliedModeAnnotation is removed from the ClusterPolicy.
+func (r *PolicyManifestReconciler) reconcileMode(ctx context.Context, polman *policyAPI.PolicyManifest, e *enforcement, driftReported bool) (modeState, error) {
+	var clusterPolicy kyvernov1.ClusterPolicy
+	if err := r.Get(ctx, types.NamespacedName{Name: polman.Name}, &clusterPolicy); err != nil {
+		// Missing policies are reported by the translation
+		if errors.IsNotFound(err) {
+			return modePending, nil
+		}
+		return modePending, err
+	}
+
+	if e.enforces(&clusterPolicy) && clusterPolicy.Annotations[AppliedModeAnnotation] == polman.Spec.Mode {
+		return modeApplied, nil
+	}
+
+	// The mode was already applied, so the ClusterPolicy was changed since
+	if clusterPolicy.Annotations[AppliedModeAnnotation] == polman.Spec.Mode {
+		if !driftReported {
+			log.Log.Info(fmt.Sprintf("ClusterPolicy %s drifted from mode %s", clusterPolicy.Name, polman.Spec.Mode))
+			r.Recorder.Eventf(polman, &clusterPolicy, corev1.EventTypeWarning, ReasonModeDrifted, ActionEnforce,
+				"ClusterPolicy %s was changed by another manager, mode %s is not applied again until it changes", clusterPolicy.Name, polman.Spec.Mode)
+			r.Recorder.Eventf(&clusterPolicy, polman, corev1.EventTypeWarning, ReasonModeDrifted, ActionEnforce,
+				"Validation failure action was changed, mode %s of PolicyManifest %s is not applied again until it changes", polman.Spec.Mode, polman.Name)
+			metrics.ModeDrifts.WithLabelValues(clusterPolicy.Name).Inc()
+		}
+		return modeDrifted, nil
+	}
+
+	if r.DryRun != nil {
+		log.Log.Info(fmt.Sprintf("Dry-run: would set ClusterPolicy %s to mode %s", clusterPolicy.Name, polman.Spec.Mode))
+		return modePending, nil
+	}
+
+	patch := client.MergeFrom(clusterPolicy.DeepCopy())
+	e.apply(&clusterPolicy)
+	if clusterPolicy.Annotations == nil {
+		clusterPolicy.Annotations = make(map[string]string)
+	}
+	clusterPolicy.Annotations[AppliedModeAnnotation] = polman.Spec.Mode
+	if err := r.Patch(ctx, &clusterPolicy, patch); err != nil {
+		return modePending, err
+	}
+
+	log.Log.Info(fmt.Sprintf("ClusterPolicy %s set to mode %s", clusterPolicy.Name, polman.Spec.Mode))
+	r.Recorder.Eventf(polman, &clusterPolicy, corev1.EventTypeNormal, ReasonModeApplied, ActionEnforce,
+		"ClusterPolicy %s set to mode %s, validation failure action %s", clusterPolicy.Name, polman.Spec.Mode, e.action)
+
+	// Warn the owners of both objects that violations are only audited until the policy is enforced
+	if e.emitWarning {
+		r.Recorder.Eventf(polman, &clusterPolicy, corev1.EventTypeWarning, ReasonWarming, ActionEnforce,
+			"ClusterPolicy %s is warming up, violations are audited and returned as admission warnings until it is enforced", clusterPolicy.Name)
+		r.Recorder.Eventf(&clusterPolicy, polman, corev1.EventTypeWarning, ReasonWarming, ActionEnforce,
+			"Warming up for PolicyManifest %s, violations are audited and returned as admission warnings until it is enforced", polman.Name)
+	}
+
+	return modeApplied, nil
+}
@@ -0,0 +1,134 @@
+package controller
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	policyAPI "github.com/giantswarm/policy-api/api/v1alpha1"
+)
+
+const (
+	// GeneratedNameSuffixLength is the length of the random suffix Kubernetes appends to metadata.generateName.
+	GeneratedNameSuffixLength = 5
+	// MinNameSegmentLength is the shortest suffix a controller appends to the name of its owner that is matched.
+	// The pod-template-hash of a ReplicaSet is a uint32 encoded digit by digit, which is shorter than 8 characters for
+	// less than 0.3% of the hashes, and the scheduled time of a Job is 8 digits long. Shorter suffixes aren't matched,
+	// so the objects of sibling owners with short names, e.g. app-evil for app, aren't exempted.
+	MinNameSegmentLength = 8
+	// MaxNameSegmentLength is the longest suffix a controller appends to the name of its owner,
+	// e.g. the pod-template-hash of a ReplicaSet or the scheduled time of a Job.
+	MaxNameSegmentLength = 10
+	// MaxOrdinalLength is the longest ordinal of a StatefulSet Pod that is matched, i.e. up to 99999 replicas.
+	MaxOrdinalLength = 5
+)
+
+// nameSegment describes the suffix appended to the name of an owner when its controller creates an object.
+type nameSegment struct {
+	// lengths are the possible lengths of the suffix.
+	lengths []int
+	// generated suffixes are appended through metadata.generateName, which truncates the owner name to MaxNameLength.
+	generated bool
+}
+
+var (
+	generatedNameSegment = nameSegment{lengths: []int{GeneratedNameSuffixLength}, generated: true}
+	hashNameSegment      = nameSegment{lengths: segmentLengths(MinNameSegmentLength, MaxNameSegmentLength)}
+	ordinalNameSegment   = nameSegment{lengths: segmentLengths(1, MaxOrdinalLength)}
+)
+
+// segmentLengths returns the lengths from minLength to maxLength.
+func segmentLengths(minLength, maxLength int) []int {
+	lengths := make([]int, 0, maxLength-minLength+1)
+	for length := minLength; length <= maxLength; length++ {
+		lengths = append(lengths, length)
+	}
+
+	return lengths
+}
+
+// ownerNameSegments returns the name segment appended at every level of the kind hierarchy below the workload.
+// Pods are created with a generated name, except the ones of StatefulSets which are numbered.
+// Other kinds are named after a hash or a timestamp, e.g. ReplicaSets and Jobs.
+func ownerNameSegments(levels [][]string) []nameSegment {
+	var segments []nameSegment
+	for depth := 1; depth < len(levels); depth++ {
+		switch {
+		case !slices.Equal(levels[depth], []string{KindPod}):
+			segments = append(segments, hashNameSegment)
+		case slices.Contains(levels[depth-1], KindStatefulSet):
+			segments = append(segments, ordinalNameSegment)
+		default:
+			segments = append(segments, generatedNameSegment)
+		}
+	}
+
+	return segments
+}
+
+// hasTargetNames reports whether target has a non-empty name. Kyverno matches every name when a ResourceFilter has none,
+// so targets without names must not be translated.
+func hasTargetNames(target policyAPI.Target) bool {
+	return slices.ContainsFunc(target.Names, func(name string) bool { return name != "" })
+}
+
+// checkTargetNames rejects targets without a non-empty name, they would exempt every object of their kind.
+// fieldName is the name of the targets in the spec, used in the error.
+func checkTargetNames(targets []policyAPI.Target, fieldName string) error {
+	for i, target := range targets {
+		if !hasTargetNames(target) {
+			return fmt.Errorf("%s[%d] of kind %s has no names and would match every %s", fieldName, i, target.Kind, target.Kind)
+		}
+	}
+
+	return nil
+}
+
+// ownerNames returns the name patterns of the objects created below the named owners, one segment per level.
+// Every generated character is matched by a single ? so objects of other owners sharing the prefix aren't matched,
+// e.g. my-app-?????????-????? matches the Pods of the Deployment my-app but not the ones of my-app-evil.
+// Siblings whose extra characters fit in a segment are still matched, e.g. the Pods of my-app-x when its pod-template-hash has 8 characters.
+func ownerNames(names []string, segments []nameSegment) []string {
+	newNames := []string{}
+	for _, name := range names {
+		switch {
+		case name == "":
+			continue
+		case len(segments) == 0 || strings.HasSuffix(name, "*"):
+			// Names with an explicit wildcard are kept as they are
+			newNames = append(newNames, name)
+			continue
+		}
+
+		patterns := []string{name}
+		for _, segment := range segments {
+			patterns = appendNameSegment(patterns, segment)
+		}
+		for _, pattern := range patterns {
+			if !slices.Contains(newNames, pattern) {
+				newNames = append(newNames, pattern)
+			}
+		}
+	}
+	return newNames
+}
+
+// appendNameSegment returns the patterns of the objects created by the owners matched by patterns.
+// Each ? stands for one character, so the truncation of generated names is applied to the patterns as it is to the names.
+func appendNameSegment(patterns []string, segment nameSegment) []string {
+	var newPatterns []string
+	for _, pattern := range patterns {
+		prefix := pattern + "-"
+		if segment.generated && len(prefix) > MaxNameLength {
+			prefix = prefix[:MaxNameLength]
+		}
+		for _, length := range segment.lengths {
+			newPattern := prefix + strings.Repeat("?", length)
+			if !slices.Contains(newPatterns, newPattern) {
+				newPatterns = append(newPatterns, newPattern)
+			}
+		}
+	}
+
+	return newPatterns
+}
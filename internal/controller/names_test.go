@@ -0,0 +1,141 @@
+package controller
+
+import (
+	"strings"
+	"testing"
+
+	policyAPI "github.com/giantswarm/policy-api/api/v1alpha1"
+	"github.com/kyverno/kyverno/ext/wildcard"
+)
+
+// generateName mirrors the apiserver, which truncates the prefix before appending the random suffix.
+func generateName(base string) string {
+	if len(base) > MaxNameLength {
+		base = base[:MaxNameLength]
+	}
+	return base + "x7k2p"
+}
+
+func matchesAny(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		if wildcard.Match(pattern, name) {
+			return true
+		}
+	}
+	return false
+}
+
+func TestOwnerNamesMatchDeploymentObjects(t *testing.T) {
+	segments := ownerNameSegments(generateExceptionKindLevels(KindDeployment, DefaultKindHierarchy()))
+
+	tests := []struct {
+		name       string
+		nameLength int
+	}{
+		{name: "short names", nameLength: 6},
+		{name: "untruncated Pod names", nameLength: 46},
+		{name: "Pod names losing the dash before their suffix", nameLength: 47},
+		{name: "Pod names truncated in the hash", nameLength: 50},
+		{name: "Pod names truncated after the Deployment name and dash", nameLength: 57},
+		{name: "Pod names truncated after the Deployment name", nameLength: 58},
+		{name: "Deployment names longer than generated names", nameLength: 63},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			deployment := strings.Repeat("a", tc.nameLength)
+			replicaSetPatterns := ownerNames([]string{deployment}, segments[:1])
+			podPatterns := ownerNames([]string{deployment}, segments[:2])
+
+			for hashLength := MinNameSegmentLength; hashLength <= MaxNameSegmentLength; hashLength++ {
+				replicaSet := deployment + "-" + strings.Repeat("b", hashLength)
+				if !matchesAny(replicaSetPatterns, replicaSet) {
+					t.Errorf("ReplicaSet %s isn't matched", replicaSet)
+				}
+
+				pod := generateName(replicaSet + "-")
+				if !matchesAny(podPatterns, pod) {
+					t.Errorf("Pod %s isn't matched", pod)
+				}
+			}
+		})
+	}
+}
+
+func TestOwnerNamesSkipSiblings(t *testing.T) {
+	segments := ownerNameSegments(generateExceptionKindLevels(KindDeployment, DefaultKindHierarchy()))
+	replicaSetPatterns := ownerNames([]string{"app"}, segments[:1])
+	podPatterns := ownerNames([]string{"app"}, segments[:2])
+
+	tests := []struct {
+		name       string
+		replicaSet string
+		pod        string
+	}{
+		{name: "ReplicaSet named like a sibling", replicaSet: "app-evil", pod: generateName("app-evil-")},
+		{name: "sibling with a suffix", replicaSet: "app-evil-6b9d8f7c4d", pod: generateName("app-evil-6b9d8f7c4d-")},
+		{name: "sibling with a hash-like suffix", replicaSet: "app-x-6b9d8f7c4d", pod: generateName("app-x-6b9d8f7c4d-")},
+		{name: "sibling with a short hash", replicaSet: "app-x-7c4d", pod: generateName("app-x-7c4d-")},
+		{name: "sibling with a longer name", replicaSet: "application-6b9d8f7c4d", pod: generateName("application-6b9d8f7c4d-")},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if matchesAny(replicaSetPatterns, tc.replicaSet) {
+				t.Errorf("ReplicaSet %s is matched", tc.replicaSet)
+			}
+			if matchesAny(podPatterns, tc.pod) {
+				t.Errorf("Pod %s is matched", tc.pod)
+			}
+		})
+	}
+}
+
+func TestOwnerNamesNumberStatefulSetPods(t *testing.T) {
+	segments := ownerNameSegments(generateExceptionKindLevels(KindStatefulSet, DefaultKindHierarchy()))
+	patterns := ownerNames([]string{"db"}, segments)
+
+	tests := []struct {
+		pod  string
+		want bool
+	}{
+		{pod: "db-0", want: true},
+		{pod: "db-12", want: true},
+		{pod: "db-evil-0", want: false},
+	}
+	for _, tc := range tests {
+		if got := matchesAny(patterns, tc.pod); got != tc.want {
+			t.Errorf("Pod %s matched = %t, want %t", tc.pod, got, tc.want)
+		}
+	}
+}
+
+func TestOwnerNamesKeepWildcards(t *testing.T) {
+	segments := ownerNameSegments(generateExceptionKindLevels(KindDeployment, DefaultKindHierarchy()))
+
+	got := ownerNames([]string{"app-*", ""}, segments)
+	if len(got) != 1 || got[0] != "app-*" {
+		t.Errorf("ownerNames() = %v, want [app-*]", got)
+	}
+}
+
+func TestTargetsWithoutNames(t *testing.T) {
+	targets := []policyAPI.Target{{Namespaces: []string{"default"}, Names: []string{""}, Kind: KindDeployment}}
+
+	tests := []struct {
+		name         string
+		nameMatching NameMatching
+	}{
+		{name: "owner matching", nameMatching: NameMatching{}},
+		{name: "prefix matching", nameMatching: NameMatching{Default: NameMatchingPrefix}},
+		{name: "exact matching", nameMatching: NameMatching{Default: NameMatchingExact}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := checkTargetNames(targets, "targets"); err == nil || !strings.Contains(err.Error(), "targets[0]") {
+				t.Errorf("checkTargetNames() = %v, want an error about targets[0]", err)
+			}
+			if filters := translateTargetsToResourceFilters(targets, DefaultKindHierarchy(), tc.nameMatching); len(filters) != 0 {
+				t.Errorf("translateTargetsToResourceFilters() = %v, want none", filters)
+			}
+		})
+	}
+}
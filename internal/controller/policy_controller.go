@@ -0,0 +1,95 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/giantswarm/kyverno-policy-operator/internal/metrics"
+	"github.com/giantswarm/kyverno-policy-operator/internal/policycache"
+	"github.com/giantswarm/kyverno-policy-operator/internal/utils"
+)
+
+// PolicyReconciler reconciles a namespaced Kyverno Policy object
+type PolicyReconciler struct {
+	client.Client
+	Scheme           *runtime.Scheme
+	Log              logr.Logger
+	PolicyCache      *policycache.Cache
+	MaxJitterPercent int
+	// PolicyUpdates receives every Policy change so dependent controllers reconcile right away.
+	PolicyUpdates []chan<- event.GenericEvent
+}
+
+//+kubebuilder:rbac:groups=kyverno.io,resources=policies,verbs=get;list;watch
+
+func (r *PolicyReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	_ = log.FromContext(ctx)
+	_ = r.Log.WithValues("policy", req.NamespacedName)
+
+	var policy kyvernov1.Policy
+
+	if err := r.Get(ctx, req.NamespacedName, &policy); err != nil {
+		// Check if the Policy was deleted
+		if errors.IsNotFound(err) {
+			if _, cached := r.PolicyCache.Get(req.String()); cached {
+				metrics.PolicyCacheSize.Set(float64(r.PolicyCache.Delete(req.String())))
+				notifyDependents(ctx, r.PolicyUpdates, &kyvernov1.Policy{ObjectMeta: metav1.ObjectMeta{Namespace: req.Namespace, Name: req.Name}})
+			}
+			return ctrl.Result{}, nil
+		}
+
+		log.Log.Error(err, "unable to fetch Policy")
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	// Namespaced policies are cached as <namespace>/<name>
+	cachedPolicy, cached := r.PolicyCache.Get(policyCacheKey(&policy))
+	var size int
+	if !policy.DeletionTimestamp.IsZero() {
+		size = r.PolicyCache.Delete(policyCacheKey(&policy))
+	} else {
+		size = r.PolicyCache.Set(policyCacheKey(&policy), &policy)
+		r.Log.Info(fmt.Sprintf("Updated cached Policy %s", policyCacheKey(&policy)))
+	}
+	metrics.PolicyCacheSize.Set(float64(size))
+
+	// Only notify dependents on changes, periodic resyncs are handled by their own requeue
+	if !cached || cachedPolicy.GetResourceVersion() != policy.ResourceVersion || !policy.DeletionTimestamp.IsZero() {
+		notifyDependents(ctx, r.PolicyUpdates, &policy)
+	}
+
+	return utils.JitterRequeue(DefaultRequeueDuration, r.MaxJitterPercent, r.Log), nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *PolicyReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&kyvernov1.Policy{}).
+		Complete(r)
+}
@@ -19,9 +19,15 @@ package controller
 import (
 	"context"
 	"fmt"
+	"maps"
+	"strings"
+	"time"
 
 	policyAPI "github.com/giantswarm/policy-api/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
@@ -30,21 +36,46 @@ import (
 
 	"github.com/go-logr/logr"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/events"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
+	"github.com/giantswarm/kyverno-policy-operator/internal/metrics"
+	"github.com/giantswarm/kyverno-policy-operator/internal/policycache"
 	"github.com/giantswarm/kyverno-policy-operator/internal/utils"
 )
 
 const (
-	KindDeployment = "Deployment"
-	KindReplicaSet = "ReplicaSet"
-	KindCronJob    = "CronJob"
-	KindJob        = "Job"
-	KindPod        = "Pod"
+	KindDeployment  = "Deployment"
+	KindReplicaSet  = "ReplicaSet"
+	KindCronJob     = "CronJob"
+	KindJob         = "Job"
+	KindPod         = "Pod"
+	KindStatefulSet = "StatefulSet"
+	KindDaemonSet   = "DaemonSet"
 )
 
+// KindHierarchy maps a workload kind to the kinds of the objects its controller creates, e.g. Deployment to ReplicaSet.
+type KindHierarchy map[string][]string
+
+// DefaultKindHierarchy returns the kind hierarchy of the built-in Kubernetes workload controllers.
+func DefaultKindHierarchy() KindHierarchy {
+	return KindHierarchy{
+		KindDeployment:  {KindReplicaSet},
+		KindReplicaSet:  {KindPod},
+		KindCronJob:     {KindJob},
+		KindJob:         {KindPod},
+		KindStatefulSet: {KindPod},
+		KindDaemonSet:   {KindPod},
+	}
+}
+
 // PolicyExceptionReconciler reconciles a PolicyException object
 type PolicyExceptionReconciler struct {
 	client.Client
@@ -53,17 +84,35 @@ type PolicyExceptionReconciler struct {
 	DestinationNamespace string
 	Background           bool
 	MaxJitterPercent     int
-	PolicyCache          map[string]kyvernov1.ClusterPolicy
+	PolicyCache          *policycache.Cache
+	Recorder             events.EventRecorder
+	// KindHierarchy is used to exempt the objects created by the targeted workloads. Defaults to DefaultKindHierarchy.
+	KindHierarchy KindHierarchy
+	// PolicyUpdates delivers the Kyverno policies that changed, the PolicyExceptions referencing them are reconciled.
+	PolicyUpdates <-chan event.GenericEvent
+	// RequireApproval keeps PolicyExceptions pending until an approver accepted their current spec.
+	RequireApproval bool
+	// DestinationRules map source namespaces to destination namespaces. The first matching rule wins, DestinationNamespace is the fallback.
+	DestinationRules []DestinationRule
+	// DryRun records the changes to Kyverno PolicyExceptions instead of applying them. The Giant Swarm PolicyExceptions aren't updated either.
+	DryRun *DryRun
 }
 
 //+kubebuilder:rbac:groups=policy.giantswarm.io,resources=policyexceptions,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=policy.giantswarm.io,resources=policyexceptions/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=policy.giantswarm.io,resources=policyexceptions/finalizers,verbs=update
+//+kubebuilder:rbac:groups=events.k8s.io,resources=events,verbs=create;patch
+//+kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
 
 func (r *PolicyExceptionReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	_ = log.FromContext(ctx)
 	_ = r.Log.WithValues("policyexception", req.NamespacedName)
 
+	// Drop the pending dry-run changes, the reconciliation records the ones still planned. Deleted PolicyExceptions leave none behind.
+	if r.DryRun != nil {
+		r.DryRun.forgetSource(dryRunSource(PolicyExceptionKind, req.NamespacedName))
+	}
+
 	var gsPolicyException policyAPI.PolicyException
 
 	if err := r.Get(ctx, req.NamespacedName, &gsPolicyException); err != nil {
@@ -71,7 +120,8 @@ func (r *PolicyExceptionReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 
 		// Check if the PolicyException was deleted
 		if errors.IsNotFound(err) {
-			// Ignore
+			// Deleted PolicyExceptions leave no gauge behind
+			metrics.UnresolvedPolicies.DeleteLabelValues(metrics.ControllerPolicyException, req.Namespace, req.Name)
 			return ctrl.Result{}, nil
 		}
 
@@ -79,7 +129,7 @@ func (r *PolicyExceptionReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
 
-	// Define namespace
+	// Define the fallback namespace, destination rules are applied once the GS PolicyException isn't being deleted
 	var namespace string
 	if r.DestinationNamespace == "" {
 		namespace = gsPolicyException.Namespace
@@ -87,47 +137,338 @@ func (r *PolicyExceptionReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 		namespace = r.DestinationNamespace
 	}
 
+	// Remove the generated Kyverno PolicyExceptions before the GS PolicyException is gone
+	if !gsPolicyException.DeletionTimestamp.IsZero() {
+		metrics.UnresolvedPolicies.DeleteLabelValues(metrics.ControllerPolicyException, gsPolicyException.Namespace, gsPolicyException.Name)
+
+		if controllerutil.ContainsFinalizer(&gsPolicyException, Finalizer) {
+			if err := r.deletePolicyExceptions(ctx, &gsPolicyException, namespace, nil); err != nil {
+				log.Log.Error(err, fmt.Sprintf("unable to delete Kyverno PolicyExceptions for PolicyException %s", gsPolicyException.Name))
+				r.Recorder.Eventf(&gsPolicyException, nil, corev1.EventTypeWarning, ReasonKyvernoRejected, ActionDelete,
+					"Unable to delete Kyverno PolicyExceptions: %s", err)
+				return ctrl.Result{}, err
+			}
+
+			if r.DryRun != nil {
+				return ctrl.Result{}, nil
+			}
+
+			controllerutil.RemoveFinalizer(&gsPolicyException, Finalizer)
+			if err := r.Update(ctx, &gsPolicyException); err != nil {
+				return ctrl.Result{}, err
+			}
+		}
+
+		return ctrl.Result{}, nil
+	}
+
+	// Add the finalizer so the generated Kyverno PolicyExceptions are cleaned up in any namespace
+	if r.DryRun == nil && controllerutil.AddFinalizer(&gsPolicyException, Finalizer) {
+		if err := r.Update(ctx, &gsPolicyException); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	namespace, err := r.destinationNamespace(ctx, &gsPolicyException, namespace)
+	if err != nil {
+		log.Log.Error(err, fmt.Sprintf("unable to resolve the destination namespace for PolicyException %s", gsPolicyException.Name))
+		return ctrl.Result{}, err
+	}
+
+	// Fetch the current status so condition transition times are preserved
+	status := PolicyExceptionStatus{}
+	if err := getStatus(ctx, r.Client, &gsPolicyException, &status); err != nil {
+		log.Log.Error(err, fmt.Sprintf("unable to fetch status for PolicyException %s", gsPolicyException.Name))
+	}
+	status.ObservedGeneration = gsPolicyException.Generation
+
+	// Only materialize approved specs, the approver identity is verified by the validating webhook.
+	// Approval is checked first so no later branch can keep an unapproved exception in place,
+	// the spec hash covers the annotations, e.g. a changed expiry needs a new approval.
+	if r.RequireApproval {
+		specHash, err := SpecHash(&gsPolicyException)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		status.SpecHash = specHash
+
+		if gsPolicyException.Annotations[ApprovedSpecHashAnnotation] != specHash {
+			if err := r.deletePolicyExceptions(ctx, &gsPolicyException, namespace, nil); err != nil {
+				log.Log.Error(err, fmt.Sprintf("unable to delete unapproved PolicyException %s", gsPolicyException.Name))
+				return ctrl.Result{}, err
+			}
+
+			message := fmt.Sprintf("Waiting for an approver to set the %s annotation to %s", ApprovedSpecHashAnnotation, specHash)
+			if !meta.IsStatusConditionPresentAndEqual(status.Conditions, ConditionApproved, metav1.ConditionFalse) {
+				r.Recorder.Eventf(&gsPolicyException, nil, corev1.EventTypeNormal, ReasonPendingApproval, ActionTranslate, "%s", message)
+			}
+
+			setConditions(&status.Conditions, status.ObservedGeneration, metav1.ConditionFalse, ReasonPendingApproval, message, ConditionApproved, ConditionReady)
+			status.KyvernoPolicyException = nil
+			r.updateStatus(ctx, &gsPolicyException, status)
+
+			// Approving the spec updates the PolicyException, which triggers a new reconciliation
+			return utils.JitterRequeue(DefaultRequeueDuration, r.MaxJitterPercent, r.Log), nil
+		}
+
+		message := fmt.Sprintf("Approved by %s", gsPolicyException.Annotations[ApprovedByAnnotation])
+		setConditions(&status.Conditions, status.ObservedGeneration, metav1.ConditionTrue, ReasonApproved, message, ConditionApproved)
+	} else {
+		status.SpecHash = ""
+		meta.RemoveStatusCondition(&status.Conditions, ConditionApproved)
+	}
+
+	// Check whether the exception is time-bound
+	expiresAt, err := parseExpiry(gsPolicyException.Annotations)
+	if err != nil {
+		log.Log.Error(err, fmt.Sprintf("unable to parse expiry for PolicyException %s", gsPolicyException.Name))
+		r.Recorder.Eventf(&gsPolicyException, nil, corev1.EventTypeWarning, ReasonInvalidExpiry, ActionTranslate, "%s", err)
+		metrics.TranslationFailures.WithLabelValues(metrics.ControllerPolicyException, ReasonInvalidExpiry).Inc()
+
+		// Fail closed, an unreadable expiry must not keep the exception alive past its deadline
+		if err := r.deletePolicyExceptions(ctx, &gsPolicyException, namespace, nil); err != nil {
+			log.Log.Error(err, fmt.Sprintf("unable to delete PolicyException %s with an invalid expiry", gsPolicyException.Name))
+			return ctrl.Result{}, err
+		}
+
+		setConditions(&status.Conditions, status.ObservedGeneration, metav1.ConditionFalse, ReasonInvalidExpiry, err.Error(), ConditionReady)
+		status.KyvernoPolicyException = nil
+		r.updateStatus(ctx, &gsPolicyException, status)
+
+		return utils.JitterRequeue(DefaultRequeueDuration, r.MaxJitterPercent, r.Log), nil
+	}
+	// Requeue precisely at the expiry deadlines instead of waiting for the next resync
+	result := requeueBeforeExpiry(utils.JitterRequeue(DefaultRequeueDuration, r.MaxJitterPercent, r.Log), expiresAt)
+
+	if expiresAt != nil {
+		if !time.Now().Before(*expiresAt) {
+			// The exception expired, remove the Kyverno PolicyException
+			if err := r.deletePolicyExceptions(ctx, &gsPolicyException, namespace, nil); err != nil {
+				log.Log.Error(err, fmt.Sprintf("unable to delete expired PolicyException %s", gsPolicyException.Name))
+				return ctrl.Result{}, err
+			}
+
+			message := fmt.Sprintf("PolicyException expired at %s", expiresAt.Format(time.RFC3339))
+			if !meta.IsStatusConditionTrue(status.Conditions, ConditionExpired) {
+				log.Log.Info(fmt.Sprintf("PolicyException %s expired, Kyverno PolicyException deleted", gsPolicyException.Name))
+				r.Recorder.Eventf(&gsPolicyException, nil, corev1.EventTypeWarning, ReasonExpired, ActionDelete, "%s", message)
+			}
+
+			setConditions(&status.Conditions, status.ObservedGeneration, metav1.ConditionTrue, ReasonExpired, message, ConditionExpired)
+			setConditions(&status.Conditions, status.ObservedGeneration, metav1.ConditionFalse, ReasonExpired, message, ConditionReady)
+			meta.RemoveStatusCondition(&status.Conditions, ConditionExpiringSoon)
+			status.KyvernoPolicyException = nil
+			r.updateStatus(ctx, &gsPolicyException, status)
+
+			// Nothing left to do until the expiry is changed
+			return ctrl.Result{}, nil
+		}
+
+		message := fmt.Sprintf("PolicyException expires at %s", expiresAt.Format(time.RFC3339))
+		if time.Until(*expiresAt) <= ExpiryWarningWindow {
+			// Only warn when entering the window, the exception is reconciled many times before it expires
+			if !meta.IsStatusConditionTrue(status.Conditions, ConditionExpiringSoon) {
+				r.Recorder.Eventf(&gsPolicyException, nil, corev1.EventTypeWarning, ReasonExpiringSoon, ActionTranslate, "%s", message)
+			}
+			setConditions(&status.Conditions, status.ObservedGeneration, metav1.ConditionTrue, ReasonExpiringSoon, message, ConditionExpiringSoon)
+		} else {
+			setConditions(&status.Conditions, status.ObservedGeneration, metav1.ConditionFalse, ReasonNotExpired, message, ConditionExpiringSoon)
+		}
+		setConditions(&status.Conditions, status.ObservedGeneration, metav1.ConditionFalse, ReasonNotExpired, message, ConditionExpired)
+	} else {
+		meta.RemoveStatusCondition(&status.Conditions, ConditionExpired)
+		meta.RemoveStatusCondition(&status.Conditions, ConditionExpiringSoon)
+	}
+
 	// Create Kyverno exception
-	// Create a policy map for storing cluster policies to extract rules later
+	// Create a policy map for storing cluster policies and namespaced policies to extract rules later
 	// TODO: Take this block out and move it to utils
-	var policies []kyvernov1.ClusterPolicy
+	var policies []kyvernov1.PolicyInterface
+	var missingPolicies []string
+	var newExceptions []kyvernov2.Exception
 	for _, policy := range gsPolicyException.Spec.Policies {
-		var kyvernoPolicy kyvernov1.ClusterPolicy
 		// Check if the policy is already in the cache
-		if cachedPolicy, exists := r.PolicyCache[policy]; exists {
-			kyvernoPolicy = cachedPolicy
+		if cachedPolicy, exists := r.PolicyCache.Get(policy); exists {
+			policies = append(policies, cachedPolicy)
 		} else {
-			// Error fetching the report
-			log.Log.Error(fmt.Errorf("policy %s not found in cache", policy), "unable to fetch Kyverno Policy from cache")
-			return utils.JitterRequeue(DefaultRequeueDuration, r.MaxJitterPercent, r.Log), nil
+			missingPolicies = append(missingPolicies, policy)
 		}
-		policies = append(policies, kyvernoPolicy)
 	}
+	status.UnresolvedPolicies = missingPolicies
+	metrics.PolicyCacheMisses.WithLabelValues(metrics.ControllerPolicyException).Add(float64(len(missingPolicies)))
+	metrics.UnresolvedPolicies.WithLabelValues(metrics.ControllerPolicyException, gsPolicyException.Namespace, gsPolicyException.Name).Set(float64(len(missingPolicies)))
+
+	resolutionMode, err := parseResolutionMode(gsPolicyException.Annotations)
+	if err != nil {
+		log.Log.Error(err, fmt.Sprintf("unable to parse resolution mode for PolicyException %s", gsPolicyException.Name))
+		r.Recorder.Eventf(&gsPolicyException, nil, corev1.EventTypeWarning, ReasonInvalidResolutionMode, ActionTranslate, "%s", err)
+		metrics.TranslationFailures.WithLabelValues(metrics.ControllerPolicyException, ReasonInvalidResolutionMode).Inc()
+
+		setConditions(&status.Conditions, status.ObservedGeneration, metav1.ConditionFalse, ReasonInvalidResolutionMode, err.Error(), ConditionPolicyResolved, ConditionReady)
+		r.updateStatus(ctx, &gsPolicyException, status)
+
+		return result, nil
+	}
+
+	switch {
+	case len(missingPolicies) == 0:
+		setConditions(&status.Conditions, status.ObservedGeneration, metav1.ConditionTrue, ReasonPoliciesResolved, "All referenced policies were found", ConditionPolicyResolved)
+	case resolutionMode == ResolutionModePartial && len(policies) != 0:
+		// Translate the policies which were found, the missing ones are retried on the next reconciliation
+		err := fmt.Errorf("policies %s not found in cache, translating the remaining policies", strings.Join(missingPolicies, ", "))
+		log.Log.Error(err, "unable to fetch Kyverno Policy from cache")
+		r.Recorder.Eventf(&gsPolicyException, nil, corev1.EventTypeWarning, ReasonPartiallyResolved, ActionTranslate, "%s", err)
+
+		setConditions(&status.Conditions, status.ObservedGeneration, metav1.ConditionFalse, ReasonPartiallyResolved, err.Error(), ConditionPolicyResolved)
+	default:
+		// Error fetching the report
+		err := fmt.Errorf("policies %s not found in cache", strings.Join(missingPolicies, ", "))
+		log.Log.Error(err, "unable to fetch Kyverno Policy from cache")
+		r.Recorder.Eventf(&gsPolicyException, nil, corev1.EventTypeWarning, ReasonPolicyNotInCache, ActionTranslate, "%s", err)
+
+		setConditions(&status.Conditions, status.ObservedGeneration, metav1.ConditionFalse, ReasonPolicyNotInCache, err.Error(), ConditionPolicyResolved, ConditionReady)
+		r.updateStatus(ctx, &gsPolicyException, status)
+
+		return result, nil
+	}
+
+	// Pod Security Standards controls narrow the exception down to the validate.podSecurity rules
+	podSecurity, err := parsePodSecurity(gsPolicyException.Annotations)
+	if err == nil && podSecurity != nil {
+		err = checkPodSecurityRules(policies)
+	}
+	if err != nil {
+		log.Log.Error(err, fmt.Sprintf("unable to translate pod security controls for PolicyException %s", gsPolicyException.Name))
+		r.Recorder.Eventf(&gsPolicyException, nil, corev1.EventTypeWarning, ReasonInvalidPodSecurity, ActionTranslate, "%s", err)
+		metrics.TranslationFailures.WithLabelValues(metrics.ControllerPolicyException, ReasonInvalidPodSecurity).Inc()
+
+		setConditions(&status.Conditions, status.ObservedGeneration, metav1.ConditionFalse, ReasonInvalidPodSecurity, err.Error(), ConditionTranslated, ConditionReady)
+		r.updateStatus(ctx, &gsPolicyException, status)
+
+		return result, nil
+	}
+
+	// Translate the referenced policies to Kyverno exceptions, honouring any rule selection
+	ruleSelection, err := parseRuleSelection(gsPolicyException.Annotations)
+	if err == nil {
+		// Rules selected for unresolved policies are applied once the policies are found
+		for _, missingPolicy := range missingPolicies {
+			delete(ruleSelection, missingPolicy)
+		}
+
+		newExceptions, err = translatePoliciesToExceptions(policies, ruleSelection, podSecurity != nil)
+	}
+	if err != nil {
+		log.Log.Error(err, fmt.Sprintf("unable to translate policies for PolicyException %s", gsPolicyException.Name))
+		r.Recorder.Eventf(&gsPolicyException, nil, corev1.EventTypeWarning, ReasonInvalidRuleSelection, ActionTranslate, "%s", err)
+		metrics.TranslationFailures.WithLabelValues(metrics.ControllerPolicyException, ReasonInvalidRuleSelection).Inc()
+
+		setConditions(&status.Conditions, status.ObservedGeneration, metav1.ConditionFalse, ReasonInvalidRuleSelection, err.Error(), ConditionTranslated, ConditionReady)
+		r.updateStatus(ctx, &gsPolicyException, status)
+
+		return result, nil
+	}
+
+	// Targets without names would exempt every object of their kind
+	if err := checkTargetNames(gsPolicyException.Spec.Targets, "targets"); err != nil {
+		log.Log.Error(err, fmt.Sprintf("unable to translate targets for PolicyException %s", gsPolicyException.Name))
+		r.Recorder.Eventf(&gsPolicyException, nil, corev1.EventTypeWarning, ReasonInvalidTargets, ActionTranslate, "%s", err)
+		metrics.TranslationFailures.WithLabelValues(metrics.ControllerPolicyException, ReasonInvalidTargets).Inc()
+
+		setConditions(&status.Conditions, status.ObservedGeneration, metav1.ConditionFalse, ReasonInvalidTargets, err.Error(), ConditionTranslated, ConditionReady)
+		r.updateStatus(ctx, &gsPolicyException, status)
+
+		return result, nil
+	}
+
+	// Targets matched by labels are added to the name-based targets
+	selectorTargets, err := parseSelectorTargets(gsPolicyException.Annotations)
+	if err != nil {
+		log.Log.Error(err, fmt.Sprintf("unable to parse selector targets for PolicyException %s", gsPolicyException.Name))
+		r.Recorder.Eventf(&gsPolicyException, nil, corev1.EventTypeWarning, ReasonInvalidSelectorTargets, ActionTranslate, "%s", err)
+		metrics.TranslationFailures.WithLabelValues(metrics.ControllerPolicyException, ReasonInvalidSelectorTargets).Inc()
+
+		setConditions(&status.Conditions, status.ObservedGeneration, metav1.ConditionFalse, ReasonInvalidSelectorTargets, err.Error(), ConditionTranslated, ConditionReady)
+		r.updateStatus(ctx, &gsPolicyException, status)
+
+		return result, nil
+	}
+
+	nameMatching, err := parseNameMatching(gsPolicyException.Annotations, len(gsPolicyException.Spec.Targets))
+	if err != nil {
+		log.Log.Error(err, fmt.Sprintf("unable to parse name matching for PolicyException %s", gsPolicyException.Name))
+		r.Recorder.Eventf(&gsPolicyException, nil, corev1.EventTypeWarning, ReasonInvalidNameMatching, ActionTranslate, "%s", err)
+		metrics.TranslationFailures.WithLabelValues(metrics.ControllerPolicyException, ReasonInvalidNameMatching).Inc()
+
+		setConditions(&status.Conditions, status.ObservedGeneration, metav1.ConditionFalse, ReasonInvalidNameMatching, err.Error(), ConditionTranslated, ConditionReady)
+		r.updateStatus(ctx, &gsPolicyException, status)
+
+		return result, nil
+	}
+
+	conditions, err := parseConditions(gsPolicyException.Annotations)
+	if err != nil {
+		log.Log.Error(err, fmt.Sprintf("unable to parse conditions for PolicyException %s", gsPolicyException.Name))
+		r.Recorder.Eventf(&gsPolicyException, nil, corev1.EventTypeWarning, ReasonInvalidConditions, ActionTranslate, "%s", err)
+		metrics.TranslationFailures.WithLabelValues(metrics.ControllerPolicyException, ReasonInvalidConditions).Inc()
+
+		setConditions(&status.Conditions, status.ObservedGeneration, metav1.ConditionFalse, ReasonInvalidConditions, err.Error(), ConditionTranslated, ConditionReady)
+		r.updateStatus(ctx, &gsPolicyException, status)
+
+		return result, nil
+	}
+
 	// Translate GiantSwarm PolicyException to Kyverno's PolicyException schema
+	translationHash, err := SpecHash(&gsPolicyException)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
 	policyException := kyvernov2.PolicyException{}
 	// Set namespace
 	policyException.Namespace = namespace
 	// Set name
 	policyException.Name = gsPolicyException.Name
 
-	// Set labels
-	policyException.Labels = generateLabels()
-	// Set ownerReferences
-	if err := controllerutil.SetControllerReference(&gsPolicyException, &policyException, r.Scheme); err != nil {
-		return ctrl.Result{}, err
-	}
-
 	// Create PolicyException
-	if op, err := controllerutil.CreateOrUpdate(ctx, r.Client, &policyException, func() error {
+	if op, err := createOrUpdatePolicyException(ctx, r.Client, r.DryRun, dryRunSource(PolicyExceptionKind, client.ObjectKeyFromObject(&gsPolicyException)), &policyException, func() error {
+
+		// Set labels
+		if policyException.Labels == nil {
+			policyException.Labels = make(map[string]string)
+		}
+		maps.Copy(policyException.Labels, generateLabels())
+		// Track the source so the PolicyException can be cleaned up in any namespace
+		setSourceMetadata(&policyException, &gsPolicyException, PolicyExceptionKind, translationHash)
+
+		// Set ownerReferences. Kubernetes garbage collection ignores cross-namespace owners,
+		// those PolicyExceptions are removed by the finalizer instead.
+		if policyException.Namespace == gsPolicyException.Namespace {
+			if err := controllerutil.SetControllerReference(&gsPolicyException, &policyException, r.Scheme); err != nil {
+				return err
+			}
+		} else if owned, err := controllerutil.HasOwnerReference(policyException.OwnerReferences, &gsPolicyException, r.Scheme); err != nil {
+			return err
+		} else if owned {
+			if err := controllerutil.RemoveOwnerReference(&gsPolicyException, &policyException, r.Scheme); err != nil {
+				return err
+			}
+		}
 
 		// Set Background behaviour
 		policyException.Spec.Background = &r.Background
 
 		// Set .Spec.Match.Any targets
-		policyException.Spec.Match.Any = translateTargetsToResourceFilters(gsPolicyException.Spec.Targets)
+		policyException.Spec.Match.Any = append(translateTargetsToResourceFilters(gsPolicyException.Spec.Targets, r.KindHierarchy, nameMatching),
+			translateSelectorTargetsToResourceFilters(selectorTargets, r.KindHierarchy)...)
+
+		// Set .Spec.Conditions
+		policyException.Spec.Conditions = conditions
+
+		// Set .Spec.PodSecurity
+		policyException.Spec.PodSecurity = podSecurity
 
 		// Set .Spec.Exceptions
-		newExceptions := translatePoliciesToExceptions(policies)
 		if !unorderedEqual(policyException.Spec.Exceptions, newExceptions) {
 			policyException.Spec.Exceptions = newExceptions
 		}
@@ -135,12 +476,91 @@ func (r *PolicyExceptionReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 		return nil
 	}); err != nil {
 		log.Log.Error(err, fmt.Sprintf("Reconciliation failed for PolicyException %s", policyException.Name))
+		r.Recorder.Eventf(&gsPolicyException, &policyException, corev1.EventTypeWarning, ReasonKyvernoRejected, ActionTranslate,
+			"Kyverno PolicyException %s/%s was rejected: %s", policyException.Namespace, policyException.Name, err)
+		metrics.TranslationFailures.WithLabelValues(metrics.ControllerPolicyException, ReasonKyvernoRejected).Inc()
+
+		setConditions(&status.Conditions, status.ObservedGeneration, metav1.ConditionFalse, ReasonKyvernoRejected, err.Error(), ConditionTranslated, ConditionReady)
+		r.updateStatus(ctx, &gsPolicyException, status)
+
 		return ctrl.Result{}, err
 	} else {
 		log.Log.Info(fmt.Sprintf("PolicyException %s: %s", policyException.Name, op))
+		recordOperationEvent(r.Recorder, &gsPolicyException, &policyException, op)
+	}
+
+	// Remove PolicyExceptions left behind in other namespaces, e.g. after the destination namespace changed
+	if err := r.deletePolicyExceptions(ctx, &gsPolicyException, namespace, &policyException); err != nil {
+		log.Log.Error(err, fmt.Sprintf("unable to delete stale Kyverno PolicyExceptions for PolicyException %s", gsPolicyException.Name))
+		return ctrl.Result{}, err
+	}
+
+	message := fmt.Sprintf("Kyverno PolicyException %s/%s is up to date", policyException.Namespace, policyException.Name)
+	setConditions(&status.Conditions, status.ObservedGeneration, metav1.ConditionTrue, ReasonReconciled, message, ConditionTranslated, ConditionReady)
+	status.KyvernoPolicyException = &KyvernoPolicyExceptionReference{
+		Name:      policyException.Name,
+		Namespace: policyException.Namespace,
+	}
+	r.updateStatus(ctx, &gsPolicyException, status)
+
+	return result, nil
+}
+
+// updateStatus writes status to the Giant Swarm PolicyException. Nothing is written in dry-run mode.
+// Failures are logged and reported but don't fail the reconciliation, e.g. when the installed CRD lacks the status subresource.
+func (r *PolicyExceptionReconciler) updateStatus(ctx context.Context, gsPolicyException *policyAPI.PolicyException, status PolicyExceptionStatus) {
+	if r.DryRun != nil {
+		return
+	}
+
+	if err := patchStatus(ctx, r.Client, gsPolicyException, status); err != nil {
+		reportStatusUpdateFailure(r.Recorder, gsPolicyException, err)
+	}
+}
+
+// deletePolicyExceptions removes the Kyverno PolicyExceptions generated from gsPolicyException in any namespace, except keep.
+// PolicyExceptions created before source tracking was introduced are found through their controller reference.
+// Deletions not scoped to gsPolicyException are refused and reported.
+func (r *PolicyExceptionReconciler) deletePolicyExceptions(ctx context.Context, gsPolicyException *policyAPI.PolicyException, namespace string, keep *kyvernov2.PolicyException) (err error) {
+	defer func() { reportBroadDelete(r.Recorder, gsPolicyException, metrics.ControllerPolicyException, err) }()
+
+	selector, err := ownedSelector(gsPolicyException)
+	if err != nil {
+		return err
+	}
+
+	var policyExceptions kyvernov2.PolicyExceptionList
+	if err := r.List(ctx, &policyExceptions, selector); err != nil {
+		return err
 	}
 
-	return utils.JitterRequeue(DefaultRequeueDuration, r.MaxJitterPercent, r.Log), nil
+	legacyPolicyException := kyvernov2.PolicyException{}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: namespace, Name: gsPolicyException.Name}, &legacyPolicyException); err == nil {
+		if legacyPolicyException.Labels[SourceUID] == "" && metav1.IsControlledBy(&legacyPolicyException, gsPolicyException) {
+			policyExceptions.Items = append(policyExceptions.Items, legacyPolicyException)
+		}
+	} else if !errors.IsNotFound(err) {
+		return err
+	}
+
+	for i := range policyExceptions.Items {
+		policyException := &policyExceptions.Items[i]
+		if keep != nil && client.ObjectKeyFromObject(policyException) == client.ObjectKeyFromObject(keep) {
+			continue
+		}
+		if err := checkOwned(policyException, gsPolicyException, gsPolicyException.Name); err != nil {
+			return err
+		}
+
+		if err := deletePolicyException(ctx, r.Client, r.DryRun, dryRunSource(PolicyExceptionKind, client.ObjectKeyFromObject(gsPolicyException)), policyException); err != nil {
+			return err
+		}
+		log.Log.Info(fmt.Sprintf("PolicyException %s deleted", client.ObjectKeyFromObject(policyException)))
+		r.Recorder.Eventf(gsPolicyException, policyException, corev1.EventTypeNormal, ReasonDeleted, ActionDelete,
+			"Kyverno PolicyException %s deleted", client.ObjectKeyFromObject(policyException))
+	}
+
+	return nil
 }
 
 // CreateOrUpdate attempts first to patch the object given but if an IsNotFound error
@@ -165,27 +585,94 @@ func (r *PolicyExceptionReconciler) CreateOrUpdate(ctx context.Context, obj clie
 }
 
 // generateKinds creates the subresources necessary for top level controllers like Deployment or StatefulSet
-func generateExceptionKinds(resourceKind string) []string {
-	exceptionKinds := []string{resourceKind}
+func generateExceptionKinds(resourceKind string, kindHierarchy KindHierarchy) []string {
+	var exceptionKinds []string
+	for _, kinds := range generateExceptionKindLevels(resourceKind, kindHierarchy) {
+		exceptionKinds = append(exceptionKinds, kinds...)
+	}
 
-	switch resourceKind {
-	case KindDeployment:
-		exceptionKinds = append(exceptionKinds, KindReplicaSet)
-	case KindCronJob:
-		exceptionKinds = append(exceptionKinds, KindJob)
+	return exceptionKinds
+}
+
+// generateExceptionKindLevels returns the kinds created below resourceKind, grouped by their depth in the hierarchy.
+// Kinds missing from the hierarchy are assumed to create Pods directly.
+func generateExceptionKindLevels(resourceKind string, kindHierarchy KindHierarchy) [][]string {
+	if kindHierarchy == nil {
+		kindHierarchy = DefaultKindHierarchy()
 	}
 
-	if resourceKind != KindPod {
-		exceptionKinds = append(exceptionKinds, KindPod)
+	if _, known := kindHierarchy[resourceKind]; !known {
+		if resourceKind == KindPod {
+			return [][]string{{resourceKind}}
+		}
+		return [][]string{{resourceKind}, {KindPod}}
 	}
 
-	return exceptionKinds
+	// Walk down the hierarchy, skipping kinds already added in case of cycles
+	levels := [][]string{{resourceKind}}
+	seen := map[string]bool{resourceKind: true}
+	for depth := 0; depth < len(levels); depth++ {
+		var childKinds []string
+		for _, kind := range levels[depth] {
+			for _, childKind := range kindHierarchy[kind] {
+				if !seen[childKind] {
+					seen[childKind] = true
+					childKinds = append(childKinds, childKind)
+				}
+			}
+		}
+		if len(childKinds) != 0 {
+			levels = append(levels, childKinds)
+		}
+	}
+
+	return levels
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *PolicyExceptionReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewControllerManagedBy(mgr).
+	if err := indexPolicyExceptionPolicies(context.Background(), mgr); err != nil {
+		return err
+	}
+
+	builder := ctrl.NewControllerManagedBy(mgr).
 		For(&policyAPI.PolicyException{}).
-		Owns(&kyvernov2.PolicyException{}).
-		Complete(r)
+		Watches(&kyvernov2.PolicyException{}, handler.EnqueueRequestsFromMapFunc(mapPolicyExceptionToSource))
+
+	if r.PolicyUpdates != nil {
+		builder = builder.WatchesRawSource(source.Channel(r.PolicyUpdates, handler.EnqueueRequestsFromMapFunc(r.mapPolicyToExceptions)))
+	}
+
+	// Namespace labels select the destination of the PolicyExceptions living in them
+	if hasNamespaceSelectors(r.DestinationRules) {
+		builder = builder.Watches(&corev1.Namespace{}, handler.EnqueueRequestsFromMapFunc(r.mapNamespaceToExceptions))
+	}
+
+	return builder.Complete(r)
+}
+
+// mapPolicyExceptionToSource enqueues the Giant Swarm PolicyException a Kyverno PolicyException was translated from.
+// Owner references can't be used since the Kyverno PolicyException may live in a different namespace.
+func mapPolicyExceptionToSource(ctx context.Context, obj client.Object) []reconcile.Request {
+	if obj.GetLabels()[ManagedBy] != ComponentName {
+		return nil
+	}
+
+	annotations := obj.GetAnnotations()
+	if annotations[SourceKind] == PolicyExceptionKind {
+		return []reconcile.Request{{NamespacedName: types.NamespacedName{
+			Namespace: annotations[SourceNamespace],
+			Name:      annotations[SourceName],
+		}}}
+	}
+
+	// PolicyExceptions created before source tracking was introduced
+	if owner := metav1.GetControllerOf(obj); owner != nil && owner.Kind == PolicyExceptionKind {
+		return []reconcile.Request{{NamespacedName: types.NamespacedName{
+			Namespace: obj.GetNamespace(),
+			Name:      owner.Name,
+		}}}
+	}
+
+	return nil
 }
@@ -18,6 +18,8 @@ package controller_test
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	policyAPI "github.com/giantswarm/policy-api/api/v1alpha1"
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
@@ -25,14 +27,21 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/events"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 
 	"github.com/giantswarm/kyverno-policy-operator/internal/controller"
+	"github.com/giantswarm/kyverno-policy-operator/internal/policycache"
 )
 
 var _ = Describe("Converting GSPolicyException to Kyverno Policy Exception", func() {
@@ -42,12 +51,14 @@ var _ = Describe("Converting GSPolicyException to Kyverno Policy Exception", fun
 		gsPolicyException      policyAPI.PolicyException
 		r                      *controller.PolicyExceptionReconciler
 		kyvernoPolicyException kyvernov2.PolicyException
-		policyCache            map[string]kyvernov1.ClusterPolicy
+		policyCache            *policycache.Cache
+		recorder               *events.FakeRecorder
 	)
 
 	BeforeEach(func() {
 		// initialize the shared PolicyCache
-		policyCache = make(map[string]kyvernov1.ClusterPolicy)
+		policyCache = policycache.New()
+		recorder = events.NewFakeRecorder(100)
 
 		// We initialize the Policy Exception Reconciler first.
 		r = &controller.PolicyExceptionReconciler{
@@ -58,6 +69,7 @@ var _ = Describe("Converting GSPolicyException to Kyverno Policy Exception", fun
 			Background:           false,
 			MaxJitterPercent:     maxJitterPercent,
 			PolicyCache:          policyCache,
+			Recorder:             recorder,
 		}
 
 		logger := zap.New(zap.WriteTo(GinkgoWriter), zap.UseDevMode(true))
@@ -123,6 +135,7 @@ var _ = Describe("Converting GSPolicyException to Kyverno Policy Exception", fun
 			Log:              logger,
 			PolicyCache:      policyCache,
 			MaxJitterPercent: maxJitterPercent,
+			Recorder:         events.NewFakeRecorder(100),
 		}
 		req := ctrl.Request{
 			NamespacedName: types.NamespacedName{
@@ -159,9 +172,13 @@ var _ = Describe("Converting GSPolicyException to Kyverno Policy Exception", fun
 	})
 
 	AfterEach(func() {
-		// Clean up the Kyverno Cluster Policy and the Giant Swarm Policy Manifest
+		// Clean up the Kyverno Cluster Policy and the Giant Swarm Policy Exception
 		Expect(k8sClient.Delete(ctx, &kyvernoClusterPolicy)).Should(Succeed())
-		Expect(k8sClient.Delete(ctx, &gsPolicyException)).Should(Succeed())
+		Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, &gsPolicyException))).Should(Succeed())
+
+		// Reconcile the deletion so the finalizer is removed
+		_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(&gsPolicyException)})
+		Expect(err).NotTo(HaveOccurred())
 	})
 
 	Context("When succesfully reconciling a GSPolicyException", func() {
@@ -189,8 +206,811 @@ var _ = Describe("Converting GSPolicyException to Kyverno Policy Exception", fun
 			Expect(kyvernoPolicyException.Spec.Match.GetKinds()).To(ConsistOf("Deployment", "ReplicaSet", "Pod"))
 			Expect(kyvernoPolicyException.Spec.Exceptions[0].PolicyName).To(Equal("disallow-privileged-containers"))
 			Expect(kyvernoPolicyException.Spec.Exceptions[0].RuleNames[0]).To(Equal("restrict-privileged-containers"))
-			Expect(kyvernoPolicyException.Spec.Match.Any[0].ResourceDescription.Names[0]).To(Equal("test-app-1*"))
+			Expect(kyvernoPolicyException.Spec.Match.Any[0].ResourceDescription.Names[0]).To(Equal("test-app-1"))
 			Expect(kyvernoPolicyException.Spec.Match.Any[0].ResourceDescription.Namespaces[0]).To(Equal("default"))
 		})
+
+		It("should report the translation result in the GSPolicyException status", func() {
+			req := ctrl.Request{
+				NamespacedName: types.NamespacedName{
+					Name:      gsPolicyException.Name,
+					Namespace: gsPolicyException.Namespace,
+				},
+			}
+			_, err := r.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+
+			// The Policy API types do not carry a status, so we read it as unstructured.
+			reconciled := unstructured.Unstructured{}
+			reconciled.SetGroupVersionKind(policyAPI.GroupVersion.WithKind("PolicyException"))
+			Expect(k8sClient.Get(ctx, req.NamespacedName, &reconciled)).To(Succeed())
+
+			conditions, found, err := unstructured.NestedSlice(reconciled.Object, "status", "conditions")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(conditions).To(ContainElement(SatisfyAll(
+				HaveKeyWithValue("type", controller.ConditionReady),
+				HaveKeyWithValue("status", "True"),
+			)))
+
+			reference, found, err := unstructured.NestedStringMap(reconciled.Object, "status", "kyvernoPolicyException")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(reference).To(HaveKeyWithValue("name", "test-policyexception"))
+			Expect(reference).To(HaveKeyWithValue("namespace", "default"))
+		})
+	})
+
+	Context("When the CRD has no status subresource", func() {
+		It("should translate the GSPolicyException without reporting the status", func() {
+			// The API server answers NotFound for status writes without the subresource
+			withWatch, err := client.NewWithWatch(cfg, client.Options{Scheme: scheme.Scheme})
+			Expect(err).NotTo(HaveOccurred())
+			r.Client = interceptor.NewClient(withWatch, interceptor.Funcs{
+				SubResourcePatch: func(ctx context.Context, c client.Client, subResourceName string, obj client.Object, patch client.Patch, opts ...client.SubResourcePatchOption) error {
+					return apierrors.NewNotFound(policyAPI.GroupVersion.WithResource("policyexceptions").GroupResource(), obj.GetName())
+				},
+			})
+			defer func() { r.Client = k8sClient }()
+
+			req := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(&gsPolicyException)}
+			_, err = r.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(k8sClient.Get(ctx, req.NamespacedName, &kyvernoPolicyException)).To(Succeed())
+			for len(recorder.Events) > 0 {
+				Expect(<-recorder.Events).NotTo(ContainSubstring(controller.ReasonStatusUpdateFailed))
+			}
+		})
+	})
+
+	Context("When auditing the Kyverno Policy Exception", func() {
+		It("should annotate the provenance of the translation", func() {
+			gsPolicyException.Annotations = map[string]string{
+				controller.CreatedByAnnotation: "jane@example.com",
+			}
+			Expect(k8sClient.Update(ctx, &gsPolicyException)).To(Succeed())
+
+			req := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(&gsPolicyException)}
+			_, err := r.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(k8sClient.Get(ctx, req.NamespacedName, &gsPolicyException)).To(Succeed())
+			specHash, err := controller.SpecHash(&gsPolicyException)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(r.Get(ctx, req.NamespacedName, &kyvernoPolicyException)).To(Succeed())
+			Expect(kyvernoPolicyException.Annotations).To(HaveKeyWithValue(controller.SourceKind, controller.PolicyExceptionKind))
+			Expect(kyvernoPolicyException.Annotations).To(HaveKeyWithValue(controller.SourceUID, string(gsPolicyException.UID)))
+			Expect(kyvernoPolicyException.Annotations).To(HaveKeyWithValue(controller.SourceGeneration, fmt.Sprint(gsPolicyException.Generation)))
+			Expect(kyvernoPolicyException.Annotations).To(HaveKeyWithValue(controller.CreatedByAnnotation, "jane@example.com"))
+			Expect(kyvernoPolicyException.Annotations).To(HaveKeyWithValue(controller.TranslationHash, specHash))
+		})
+
+		It("should not change the translation hash when only the creator changes", func() {
+			req := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(&gsPolicyException)}
+			specHash, err := controller.SpecHash(&gsPolicyException)
+			Expect(err).NotTo(HaveOccurred())
+
+			gsPolicyException.Annotations = map[string]string{
+				controller.CreatedByAnnotation: "john@example.com",
+			}
+			Expect(controller.SpecHash(&gsPolicyException)).To(Equal(specHash))
+
+			Expect(k8sClient.Update(ctx, &gsPolicyException)).To(Succeed())
+			_, err = r.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(r.Get(ctx, req.NamespacedName, &kyvernoPolicyException)).To(Succeed())
+			Expect(kyvernoPolicyException.Annotations).To(HaveKeyWithValue(controller.CreatedByAnnotation, "john@example.com"))
+			Expect(kyvernoPolicyException.Annotations).To(HaveKeyWithValue(controller.TranslationHash, specHash))
+		})
+
+		It("should not take the creator from the field managers", func() {
+			req := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(&gsPolicyException)}
+			_, err := r.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+
+			// A second manager touches the source after its creation
+			Expect(k8sClient.Get(ctx, req.NamespacedName, &gsPolicyException)).To(Succeed())
+			gsPolicyException.Spec.Targets[0].Names = []string{"test-app-2"}
+			Expect(k8sClient.Update(ctx, &gsPolicyException, client.FieldOwner("kubectl-edit"))).To(Succeed())
+			Expect(gsPolicyException.ManagedFields).To(ContainElement(HaveField("Manager", "kubectl-edit")))
+
+			_, err = r.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(r.Get(ctx, req.NamespacedName, &kyvernoPolicyException)).To(Succeed())
+			Expect(kyvernoPolicyException.Annotations).NotTo(HaveKey(controller.CreatedByAnnotation))
+		})
+	})
+
+	Context("When referencing a namespaced Kyverno Policy", func() {
+		var kyvernoPolicy kyvernov1.Policy
+
+		BeforeEach(func() {
+			kyvernoPolicy = kyvernov1.Policy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "disallow-privileged-containers",
+					Namespace: "default",
+				},
+				Spec: *kyvernoClusterPolicy.Spec.DeepCopy(),
+			}
+			Expect(k8sClient.Create(ctx, &kyvernoPolicy)).To(Succeed())
+
+			// Cache the namespaced Policy
+			policyReconciler := &controller.PolicyReconciler{
+				Client:           k8sClient,
+				Scheme:           scheme.Scheme,
+				Log:              logger,
+				PolicyCache:      policyCache,
+				MaxJitterPercent: maxJitterPercent,
+			}
+			_, err := policyReconciler.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(&kyvernoPolicy)})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			Expect(k8sClient.Delete(ctx, &kyvernoPolicy)).To(Succeed())
+		})
+
+		It("should reference the Policy as namespace/name in the Kyverno Policy Exception", func() {
+			gsPolicyException.Spec.Policies = []string{"default/disallow-privileged-containers"}
+			Expect(k8sClient.Update(ctx, &gsPolicyException)).To(Succeed())
+
+			req := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(&gsPolicyException)}
+			_, err := r.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(r.Get(ctx, req.NamespacedName, &kyvernoPolicyException)).To(Succeed())
+			Expect(kyvernoPolicyException.Spec.Exceptions).To(HaveLen(1))
+			Expect(kyvernoPolicyException.Spec.Exceptions[0].PolicyName).To(Equal("default/disallow-privileged-containers"))
+			Expect(kyvernoPolicyException.Spec.Exceptions[0].RuleNames).To(ConsistOf("restrict-privileged-containers"))
+		})
+	})
+
+	Context("When the destination namespace differs from the GSPolicyException namespace", func() {
+		It("should delete the Kyverno Policy Exception when the GSPolicyException is deleted", func() {
+			r.DestinationNamespace = "kube-public"
+			req := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(&gsPolicyException)}
+
+			_, err := r.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+
+			key := types.NamespacedName{Namespace: "kube-public", Name: gsPolicyException.Name}
+			Expect(k8sClient.Get(ctx, key, &kyvernoPolicyException)).To(Succeed())
+			Expect(kyvernoPolicyException.OwnerReferences).To(BeEmpty())
+			Expect(kyvernoPolicyException.Labels).To(HaveKeyWithValue(controller.SourceUID, Not(BeEmpty())))
+
+			// Deleting the GSPolicyException must not orphan the cross-namespace Kyverno Policy Exception
+			Expect(k8sClient.Delete(ctx, &gsPolicyException)).To(Succeed())
+			_, err = r.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = k8sClient.Get(ctx, key, &kyvernoPolicyException)
+			Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		})
+	})
+
+	Context("When destination rules are configured", func() {
+		It("should create the Kyverno Policy Exception in the destination of the first matching rule", func() {
+			r.DestinationRules = []controller.DestinationRule{
+				{Namespaces: []string{"tenant-a"}, Destination: "kube-system"},
+				{
+					NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"kubernetes.io/metadata.name": "default"}},
+					Destination:       "kube-public",
+				},
+			}
+			req := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(&gsPolicyException)}
+
+			_, err := r.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+
+			key := types.NamespacedName{Namespace: "kube-public", Name: gsPolicyException.Name}
+			Expect(k8sClient.Get(ctx, key, &kyvernoPolicyException)).To(Succeed())
+
+			// Falling back to the destination namespace removes the Kyverno Policy Exception from the previous destination
+			r.DestinationRules = nil
+			_, err = r.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(r.Get(ctx, req.NamespacedName, &kyvernoPolicyException)).To(Succeed())
+			err = k8sClient.Get(ctx, key, &kyvernoPolicyException)
+			Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		})
+	})
+
+	Context("When running in dry-run mode", func() {
+		It("should record the changes instead of applying them", func() {
+			r.DryRun = controller.NewDryRun()
+			req := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(&gsPolicyException)}
+
+			_, err := r.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = r.Get(ctx, req.NamespacedName, &kyvernoPolicyException)
+			Expect(apierrors.IsNotFound(err)).To(BeTrue())
+			Expect(k8sClient.Get(ctx, req.NamespacedName, &gsPolicyException)).To(Succeed())
+			Expect(gsPolicyException.Finalizers).To(BeEmpty())
+
+			diffs := r.DryRun.Diffs()
+			Expect(diffs).To(HaveLen(1))
+			Expect(diffs[0].Operation).To(Equal(controller.DryRunCreate))
+			Expect(diffs[0].Source).To(Equal("PolicyException default/test-policyexception"))
+			Expect(diffs[0].Patch).NotTo(BeEmpty())
+
+			// Apply the changes, then preview a flag change
+			dryRun := r.DryRun
+			r.DryRun = nil
+			_, err = r.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+
+			r.DryRun = dryRun
+			r.Background = true
+			_, err = r.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+
+			diffs = r.DryRun.Diffs()
+			Expect(diffs).To(HaveLen(1))
+			Expect(diffs[0].Operation).To(Equal(controller.DryRunUpdate))
+			Expect(diffs[0].Patch).To(HaveLen(1))
+			Expect(diffs[0].Patch[0].Path).To(Equal("/spec/background"))
+			Expect(diffs[0].Patch[0].Value).To(BeTrue())
+
+			Expect(r.Get(ctx, req.NamespacedName, &kyvernoPolicyException)).To(Succeed())
+			Expect(*kyvernoPolicyException.Spec.Background).To(BeFalse())
+		})
+
+		It("should drop the changes which are no longer planned", func() {
+			r.DryRun = controller.NewDryRun()
+			req := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(&gsPolicyException)}
+
+			_, err := r.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(r.DryRun.Diffs()).To(HaveLen(1))
+
+			// The Kyverno Policy Exception planned in the previous destination is dropped
+			r.DestinationNamespace = "kube-public"
+			_, err = r.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+
+			diffs := r.DryRun.Diffs()
+			Expect(diffs).To(HaveLen(1))
+			Expect(diffs[0].Namespace).To(Equal("kube-public"))
+
+			// Without a finalizer in dry-run mode, the deleted GS PolicyException is simply gone
+			Expect(k8sClient.Delete(ctx, &gsPolicyException)).To(Succeed())
+			_, err = r.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(r.DryRun.Diffs()).To(BeEmpty())
+		})
+	})
+
+	Context("When selecting a subset of rules", func() {
+		It("should reject rules which are not part of the policy", func() {
+			gsPolicyException.Annotations = map[string]string{
+				controller.RulesAnnotation: `{"disallow-privileged-containers": ["unknown-rule"]}`,
+			}
+			Expect(k8sClient.Update(ctx, &gsPolicyException)).To(Succeed())
+
+			req := ctrl.Request{
+				NamespacedName: types.NamespacedName{
+					Name:      gsPolicyException.Name,
+					Namespace: gsPolicyException.Namespace,
+				},
+			}
+			_, err := r.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+
+			reconciled := unstructured.Unstructured{}
+			reconciled.SetGroupVersionKind(policyAPI.GroupVersion.WithKind("PolicyException"))
+			Expect(k8sClient.Get(ctx, req.NamespacedName, &reconciled)).To(Succeed())
+
+			conditions, _, err := unstructured.NestedSlice(reconciled.Object, "status", "conditions")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(conditions).To(ContainElement(SatisfyAll(
+				HaveKeyWithValue("type", controller.ConditionTranslated),
+				HaveKeyWithValue("status", "False"),
+				HaveKeyWithValue("reason", controller.ReasonInvalidRuleSelection),
+			)))
+		})
+	})
+
+	Context("When the GSPolicyException is time-bound", func() {
+		var req ctrl.Request
+
+		BeforeEach(func() {
+			req = ctrl.Request{
+				NamespacedName: types.NamespacedName{
+					Name:      gsPolicyException.Name,
+					Namespace: gsPolicyException.Namespace,
+				},
+			}
+		})
+
+		It("should requeue no later than the expiry deadline", func() {
+			gsPolicyException.Annotations = map[string]string{
+				controller.ExpiresAtAnnotation: time.Now().Add(time.Minute).Format(time.RFC3339),
+			}
+			Expect(k8sClient.Update(ctx, &gsPolicyException)).To(Succeed())
+
+			result, err := r.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(BeNumerically("<=", time.Minute))
+		})
+
+		It("should warn once when the expiry is near", func() {
+			gsPolicyException.Annotations = map[string]string{
+				controller.ExpiresAtAnnotation: time.Now().Add(time.Hour).Format(time.RFC3339),
+			}
+			Expect(k8sClient.Update(ctx, &gsPolicyException)).To(Succeed())
+
+			_, err := r.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+			Eventually(recorder.Events).Should(Receive(ContainSubstring(controller.ReasonExpiringSoon)))
+
+			// Drain the events of the first reconciliation
+			for len(recorder.Events) > 0 {
+				<-recorder.Events
+			}
+
+			_, err = r.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+			Consistently(recorder.Events).ShouldNot(Receive(ContainSubstring(controller.ReasonExpiringSoon)))
+		})
+
+		It("should delete the Kyverno Policy Exception once expired", func() {
+			gsPolicyException.Annotations = map[string]string{
+				controller.ExpiresAtAnnotation: time.Now().Add(-time.Minute).Format(time.RFC3339),
+			}
+			Expect(k8sClient.Update(ctx, &gsPolicyException)).To(Succeed())
+
+			result, err := r.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(BeZero())
+
+			err = r.Get(ctx, req.NamespacedName, &kyvernoPolicyException)
+			Expect(apierrors.IsNotFound(err)).To(BeTrue())
+			Expect(recorder.Events).To(Receive(ContainSubstring(controller.ReasonExpired)))
+		})
+
+		It("should delete the Kyverno Policy Exception when the expiry is invalid", func() {
+			_, err := r.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(r.Get(ctx, req.NamespacedName, &kyvernoPolicyException)).To(Succeed())
+
+			Expect(k8sClient.Get(ctx, req.NamespacedName, &gsPolicyException)).To(Succeed())
+			gsPolicyException.Annotations = map[string]string{
+				controller.ExpiresAtAnnotation: "never",
+			}
+			Expect(k8sClient.Update(ctx, &gsPolicyException)).To(Succeed())
+
+			_, err = r.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = r.Get(ctx, req.NamespacedName, &kyvernoPolicyException)
+			Expect(apierrors.IsNotFound(err)).To(BeTrue())
+			Eventually(recorder.Events).Should(Receive(ContainSubstring(controller.ReasonInvalidExpiry)))
+
+			reconciled := unstructured.Unstructured{}
+			reconciled.SetGroupVersionKind(policyAPI.GroupVersion.WithKind("PolicyException"))
+			Expect(k8sClient.Get(ctx, req.NamespacedName, &reconciled)).To(Succeed())
+			conditions, _, err := unstructured.NestedSlice(reconciled.Object, "status", "conditions")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(conditions).To(ContainElement(SatisfyAll(
+				HaveKeyWithValue("type", controller.ConditionReady),
+				HaveKeyWithValue("status", "False"),
+				HaveKeyWithValue("reason", controller.ReasonInvalidExpiry),
+			)))
+		})
+	})
+
+	Context("When matching target names", func() {
+		reconcileResourceFilters := func() kyvernov1.ResourceFilters {
+			Expect(k8sClient.Update(ctx, &gsPolicyException)).To(Succeed())
+
+			req := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(&gsPolicyException)}
+			_, err := r.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(r.Get(ctx, req.NamespacedName, &kyvernoPolicyException)).To(Succeed())
+			return kyvernoPolicyException.Spec.Match.Any
+		}
+
+		It("should derive the names of owned objects by default", func() {
+			resourceFilters := reconcileResourceFilters()
+
+			Expect(resourceFilters).To(HaveLen(3))
+			Expect(resourceFilters[0].ResourceDescription.Kinds).To(ConsistOf("Deployment"))
+			Expect(resourceFilters[0].ResourceDescription.Names).To(ConsistOf("test-app-1"))
+			Expect(resourceFilters[1].ResourceDescription.Kinds).To(ConsistOf("ReplicaSet"))
+			// One pattern per pod-template-hash length
+			Expect(resourceFilters[1].ResourceDescription.Names).To(HaveLen(controller.MaxNameSegmentLength - controller.MinNameSegmentLength + 1))
+			Expect(resourceFilters[1].ResourceDescription.Names).To(ContainElement("test-app-1-??????????"))
+			Expect(resourceFilters[2].ResourceDescription.Kinds).To(ConsistOf("Pod"))
+			Expect(resourceFilters[2].ResourceDescription.Names).To(HaveLen(controller.MaxNameSegmentLength - controller.MinNameSegmentLength + 1))
+			Expect(resourceFilters[2].ResourceDescription.Names).To(ContainElement("test-app-1-??????????-?????"))
+		})
+
+		It("should honour the mode chosen per target", func() {
+			gsPolicyException.Spec.Targets = append(gsPolicyException.Spec.Targets,
+				policyAPI.Target{Namespaces: []string{"default"}, Names: []string{"test-pod"}, Kind: controller.KindPod})
+			gsPolicyException.Annotations = map[string]string{controller.NameMatchingAnnotation: "prefix,1=exact"}
+			resourceFilters := reconcileResourceFilters()
+
+			Expect(resourceFilters).To(HaveLen(2))
+			Expect(resourceFilters[0].ResourceDescription.Names).To(ConsistOf("test-app-1*"))
+			Expect(resourceFilters[0].ResourceDescription.Kinds).To(ConsistOf("Deployment", "ReplicaSet", "Pod"))
+			Expect(resourceFilters[1].ResourceDescription.Names).To(ConsistOf("test-pod"))
+			Expect(resourceFilters[1].ResourceDescription.Kinds).To(ConsistOf("Pod"))
+		})
+
+		It("should reject overrides for unknown targets", func() {
+			gsPolicyException.Annotations = map[string]string{controller.NameMatchingAnnotation: "5=exact"}
+			Expect(k8sClient.Update(ctx, &gsPolicyException)).To(Succeed())
+
+			req := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(&gsPolicyException)}
+			_, err := r.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(recorder.Events).To(Receive(ContainSubstring(controller.ReasonInvalidNameMatching)))
+		})
+
+		It("should reject targets with empty names", func() {
+			// Kyverno matches every name when a ResourceFilter has none
+			gsPolicyException.Spec.Targets[0].Names = []string{""}
+			Expect(k8sClient.Update(ctx, &gsPolicyException)).To(Succeed())
+
+			req := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(&gsPolicyException)}
+			_, err := r.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(recorder.Events).To(Receive(ContainSubstring(controller.ReasonInvalidTargets)))
+			err = r.Get(ctx, req.NamespacedName, &kyvernoPolicyException)
+			Expect(apierrors.IsNotFound(err)).To(BeTrue())
+
+			reconciled := unstructured.Unstructured{}
+			reconciled.SetGroupVersionKind(policyAPI.GroupVersion.WithKind(controller.PolicyExceptionKind))
+			Expect(k8sClient.Get(ctx, req.NamespacedName, &reconciled)).To(Succeed())
+
+			conditions, found, err := unstructured.NestedSlice(reconciled.Object, "status", "conditions")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(conditions).To(ContainElement(SatisfyAll(
+				HaveKeyWithValue("type", controller.ConditionTranslated),
+				HaveKeyWithValue("status", "False"),
+				HaveKeyWithValue("reason", controller.ReasonInvalidTargets),
+			)))
+		})
+	})
+
+	Context("When targeting workloads managed by custom controllers", func() {
+		It("should exempt the kinds created by the workload according to the kind hierarchy", func() {
+			r.KindHierarchy = controller.DefaultKindHierarchy()
+			r.KindHierarchy["Rollout"] = []string{controller.KindReplicaSet}
+
+			// Prefix matching generates a single ResourceFilter per target
+			gsPolicyException.Annotations = map[string]string{controller.NameMatchingAnnotation: controller.NameMatchingPrefix}
+			gsPolicyException.Spec.Targets = []policyAPI.Target{
+				{Namespaces: []string{"default"}, Names: []string{"test-app-1"}, Kind: "Rollout"},
+				{Namespaces: []string{"default"}, Names: []string{"test-app-2"}, Kind: controller.KindStatefulSet},
+				{Namespaces: []string{"default"}, Names: []string{"test-app-3"}, Kind: "MachinePool"},
+			}
+			Expect(k8sClient.Update(ctx, &gsPolicyException)).To(Succeed())
+
+			req := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(&gsPolicyException)}
+			_, err := r.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(r.Get(ctx, req.NamespacedName, &kyvernoPolicyException)).To(Succeed())
+			Expect(kyvernoPolicyException.Spec.Match.Any).To(HaveLen(3))
+			Expect(kyvernoPolicyException.Spec.Match.Any[0].ResourceDescription.Kinds).To(Equal([]string{"Rollout", "ReplicaSet", "Pod"}))
+			Expect(kyvernoPolicyException.Spec.Match.Any[1].ResourceDescription.Kinds).To(Equal([]string{"StatefulSet", "Pod"}))
+			// Kinds missing from the hierarchy keep exempting their Pods
+			Expect(kyvernoPolicyException.Spec.Match.Any[2].ResourceDescription.Kinds).To(Equal([]string{"MachinePool", "Pod"}))
+		})
+	})
+
+	Context("When targeting workloads by labels", func() {
+		It("should translate the selector targets to Kyverno selectors", func() {
+			gsPolicyException.Annotations = map[string]string{
+				controller.SelectorTargetsAnnotation: `[{"kind": "Deployment", "namespaces": ["default"], "selector": {"matchLabels": {"app.kubernetes.io/name": "test-app"}}}]`,
+			}
+			Expect(k8sClient.Update(ctx, &gsPolicyException)).To(Succeed())
+
+			req := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(&gsPolicyException)}
+			_, err := r.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(r.Get(ctx, req.NamespacedName, &kyvernoPolicyException)).To(Succeed())
+			// One ResourceFilter per kind of the name-based target, followed by the selector target
+			Expect(kyvernoPolicyException.Spec.Match.Any).To(HaveLen(4))
+			Expect(kyvernoPolicyException.Spec.Match.Any[0].ResourceDescription.Names).To(ConsistOf("test-app-1"))
+
+			selectorFilter := kyvernoPolicyException.Spec.Match.Any[3].ResourceDescription
+			Expect(selectorFilter.Names).To(BeEmpty())
+			Expect(selectorFilter.Namespaces).To(ConsistOf("default"))
+			Expect(selectorFilter.Kinds).To(ConsistOf("Deployment", "ReplicaSet", "Pod"))
+			Expect(selectorFilter.Selector.MatchLabels).To(HaveKeyWithValue("app.kubernetes.io/name", "test-app"))
+		})
+
+		It("should reject targets without selectors", func() {
+			gsPolicyException.Annotations = map[string]string{
+				controller.SelectorTargetsAnnotation: `[{"kind": "Deployment"}]`,
+			}
+			Expect(k8sClient.Update(ctx, &gsPolicyException)).To(Succeed())
+
+			req := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(&gsPolicyException)}
+			_, err := r.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(recorder.Events).To(Receive(ContainSubstring(controller.ReasonInvalidSelectorTargets)))
+			err = r.Get(ctx, req.NamespacedName, &kyvernoPolicyException)
+			Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		})
+	})
+
+	Context("When restricting the exception with conditions", func() {
+		It("should translate the conditions to the Kyverno Policy Exception", func() {
+			gsPolicyException.Annotations = map[string]string{
+				controller.ConditionsAnnotation: `{"all": [{"key": "{{ request.object.spec.containers[].image }}", "operator": "AllIn", "value": ["ghcr.io/giantswarm/*"]}]}`,
+			}
+			Expect(k8sClient.Update(ctx, &gsPolicyException)).To(Succeed())
+
+			req := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(&gsPolicyException)}
+			_, err := r.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(r.Get(ctx, req.NamespacedName, &kyvernoPolicyException)).To(Succeed())
+			Expect(kyvernoPolicyException.Spec.Conditions).NotTo(BeNil())
+			Expect(kyvernoPolicyException.Spec.Conditions.AllConditions).To(HaveLen(1))
+			condition := kyvernoPolicyException.Spec.Conditions.AllConditions[0]
+			Expect(condition.Operator).To(Equal(kyvernov2.ConditionOperators["AllIn"]))
+			Expect(condition.GetKey()).To(Equal("{{ request.object.spec.containers[].image }}"))
+			Expect(condition.GetValue()).To(ConsistOf("ghcr.io/giantswarm/*"))
+		})
+
+		It("should reject unsupported operators", func() {
+			gsPolicyException.Annotations = map[string]string{
+				controller.ConditionsAnnotation: `{"any": [{"key": "{{ request.operation }}", "operator": "Matches", "value": "CREATE"}]}`,
+			}
+			Expect(k8sClient.Update(ctx, &gsPolicyException)).To(Succeed())
+
+			req := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(&gsPolicyException)}
+			_, err := r.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(recorder.Events).To(Receive(ContainSubstring(controller.ReasonInvalidConditions)))
+			err = r.Get(ctx, req.NamespacedName, &kyvernoPolicyException)
+			Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		})
+	})
+
+	Context("When exempting Pod Security Standards controls", func() {
+		BeforeEach(func() {
+			// Cache a policy mixing a validate.podSecurity rule with a pattern rule
+			podSecurityPolicy := kyvernoClusterPolicy.DeepCopy()
+			podSecurityPolicy.Name = "pod-security-baseline"
+			podSecurityPolicy.Spec.Rules = append(podSecurityPolicy.Spec.Rules, kyvernov1.Rule{
+				Name: "baseline",
+				Validation: &kyvernov1.Validation{
+					PodSecurity: &kyvernov1.PodSecurity{Level: "baseline", Version: "latest"},
+				},
+			})
+			policyCache.Set(podSecurityPolicy.Name, podSecurityPolicy)
+		})
+
+		It("should only exempt the controls from the validate.podSecurity rules", func() {
+			gsPolicyException.Spec.Policies = []string{"pod-security-baseline"}
+			gsPolicyException.Annotations = map[string]string{
+				controller.PodSecurityAnnotation: `[{"controlName": "Capabilities", "images": ["ghcr.io/giantswarm/*"], "restrictedField": "spec.containers[*].securityContext.capabilities.add", "values": ["NET_ADMIN"]}]`,
+			}
+			Expect(k8sClient.Update(ctx, &gsPolicyException)).To(Succeed())
+
+			req := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(&gsPolicyException)}
+			_, err := r.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(r.Get(ctx, req.NamespacedName, &kyvernoPolicyException)).To(Succeed())
+			Expect(kyvernoPolicyException.Spec.Exceptions).To(HaveLen(1))
+			Expect(kyvernoPolicyException.Spec.Exceptions[0].RuleNames).To(ConsistOf("baseline"))
+			Expect(kyvernoPolicyException.Spec.PodSecurity).To(HaveLen(1))
+			Expect(kyvernoPolicyException.Spec.PodSecurity[0].ControlName).To(Equal("Capabilities"))
+			Expect(kyvernoPolicyException.Spec.PodSecurity[0].Values).To(ConsistOf("NET_ADMIN"))
+		})
+
+		It("should reject container level controls without images", func() {
+			gsPolicyException.Spec.Policies = []string{"pod-security-baseline"}
+			gsPolicyException.Annotations = map[string]string{
+				controller.PodSecurityAnnotation: `[{"controlName": "Capabilities"}]`,
+			}
+			Expect(k8sClient.Update(ctx, &gsPolicyException)).To(Succeed())
+
+			req := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(&gsPolicyException)}
+			_, err := r.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(recorder.Events).To(Receive(ContainSubstring(controller.ReasonInvalidPodSecurity)))
+			err = r.Get(ctx, req.NamespacedName, &kyvernoPolicyException)
+			Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		})
+
+		It("should reject policies without validate.podSecurity rules", func() {
+			gsPolicyException.Annotations = map[string]string{
+				controller.PodSecurityAnnotation: `[{"controlName": "Host Namespaces"}]`,
+			}
+			Expect(k8sClient.Update(ctx, &gsPolicyException)).To(Succeed())
+
+			req := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(&gsPolicyException)}
+			_, err := r.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(recorder.Events).To(Receive(ContainSubstring(controller.ReasonInvalidPodSecurity)))
+			err = r.Get(ctx, req.NamespacedName, &kyvernoPolicyException)
+			Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		})
+	})
+
+	Context("When only some referenced policies are cached", func() {
+		BeforeEach(func() {
+			gsPolicyException.Spec.Policies = append(gsPolicyException.Spec.Policies, "retired-policy")
+		})
+
+		It("should skip the translation in strict mode", func() {
+			Expect(k8sClient.Update(ctx, &gsPolicyException)).To(Succeed())
+
+			req := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(&gsPolicyException)}
+			_, err := r.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = r.Get(ctx, req.NamespacedName, &kyvernoPolicyException)
+			Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		})
+
+		It("should translate the resolvable policies in partial mode", func() {
+			gsPolicyException.Annotations = map[string]string{controller.ResolutionModeAnnotation: controller.ResolutionModePartial}
+			Expect(k8sClient.Update(ctx, &gsPolicyException)).To(Succeed())
+
+			req := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(&gsPolicyException)}
+			result, err := r.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(BeNumerically(">", 0))
+
+			Expect(r.Get(ctx, req.NamespacedName, &kyvernoPolicyException)).To(Succeed())
+			Expect(kyvernoPolicyException.Spec.Exceptions).To(HaveLen(1))
+			Expect(kyvernoPolicyException.Spec.Exceptions[0].PolicyName).To(Equal("disallow-privileged-containers"))
+
+			Expect(recorder.Events).To(Receive(ContainSubstring(controller.ReasonPartiallyResolved)))
+
+			reconciled := unstructured.Unstructured{}
+			reconciled.SetGroupVersionKind(policyAPI.GroupVersion.WithKind("PolicyException"))
+			Expect(k8sClient.Get(ctx, req.NamespacedName, &reconciled)).To(Succeed())
+			unresolved, found, err := unstructured.NestedStringSlice(reconciled.Object, "status", "unresolvedPolicies")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(unresolved).To(ConsistOf("retired-policy"))
+		})
+	})
+
+	Context("When approval is required", func() {
+		BeforeEach(func() {
+			r.RequireApproval = true
+		})
+
+		It("should only create the Kyverno Policy Exception while the approved spec hash matches", func() {
+			req := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(&gsPolicyException)}
+			_, err := r.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+
+			// Pending until approved
+			err = r.Get(ctx, req.NamespacedName, &kyvernoPolicyException)
+			Expect(apierrors.IsNotFound(err)).To(BeTrue())
+			Expect(recorder.Events).To(Receive(ContainSubstring(controller.ReasonPendingApproval)))
+
+			// Approve the current spec
+			Expect(k8sClient.Get(ctx, req.NamespacedName, &gsPolicyException)).To(Succeed())
+			specHash, err := controller.SpecHash(&gsPolicyException)
+			Expect(err).NotTo(HaveOccurred())
+			gsPolicyException.Annotations = map[string]string{
+				controller.ApprovedSpecHashAnnotation: specHash,
+				controller.ApprovedByAnnotation:       "security-team",
+			}
+			Expect(k8sClient.Update(ctx, &gsPolicyException)).To(Succeed())
+
+			_, err = r.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(r.Get(ctx, req.NamespacedName, &kyvernoPolicyException)).To(Succeed())
+
+			// Changing the spec revokes the approval
+			gsPolicyException.Spec.Targets[0].Names = append(gsPolicyException.Spec.Targets[0].Names, "test-app-2")
+			Expect(k8sClient.Update(ctx, &gsPolicyException)).To(Succeed())
+
+			_, err = r.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+			err = r.Get(ctx, req.NamespacedName, &kyvernoPolicyException)
+			Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		})
+
+		It("should revoke the approval when the expiry changes, even to an invalid value", func() {
+			req := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(&gsPolicyException)}
+			specHash, err := controller.SpecHash(&gsPolicyException)
+			Expect(err).NotTo(HaveOccurred())
+			gsPolicyException.Annotations = map[string]string{
+				controller.ApprovedSpecHashAnnotation: specHash,
+				controller.ApprovedByAnnotation:       "security-team",
+			}
+			Expect(k8sClient.Update(ctx, &gsPolicyException)).To(Succeed())
+
+			_, err = r.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(r.Get(ctx, req.NamespacedName, &kyvernoPolicyException)).To(Succeed())
+
+			// The approval gate runs before the expiry is parsed
+			Expect(k8sClient.Get(ctx, req.NamespacedName, &gsPolicyException)).To(Succeed())
+			gsPolicyException.Annotations[controller.ExpiresAtAnnotation] = "never"
+			Expect(k8sClient.Update(ctx, &gsPolicyException)).To(Succeed())
+
+			_, err = r.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+			err = r.Get(ctx, req.NamespacedName, &kyvernoPolicyException)
+			Expect(apierrors.IsNotFound(err)).To(BeTrue())
+			Eventually(recorder.Events).Should(Receive(ContainSubstring(controller.ReasonPendingApproval)))
+		})
+	})
+
+	Context("When a referenced ClusterPolicy changes", func() {
+		It("should notify the dependent controllers", func() {
+			policyUpdates := make(chan event.GenericEvent, 1)
+			clusterPolicyReconciler := &controller.ClusterPolicyReconciler{
+				Client:           k8sClient,
+				Scheme:           scheme.Scheme,
+				Log:              logger,
+				PolicyCache:      policyCache,
+				MaxJitterPercent: maxJitterPercent,
+				Recorder:         events.NewFakeRecorder(100),
+				PolicyUpdates:    []chan<- event.GenericEvent{policyUpdates},
+			}
+			req := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(&kyvernoClusterPolicy)}
+
+			// Resyncing an unchanged ClusterPolicy doesn't notify the dependents
+			_, err := clusterPolicyReconciler.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(policyUpdates).NotTo(Receive())
+
+			// Add a rule to the ClusterPolicy
+			Expect(k8sClient.Get(ctx, req.NamespacedName, &kyvernoClusterPolicy)).To(Succeed())
+			newRule := *kyvernoClusterPolicy.Spec.Rules[0].DeepCopy()
+			newRule.Name = "restrict-privilege-escalation"
+			kyvernoClusterPolicy.Spec.Rules = append(kyvernoClusterPolicy.Spec.Rules, newRule)
+			Expect(k8sClient.Update(ctx, &kyvernoClusterPolicy)).To(Succeed())
+
+			_, err = clusterPolicyReconciler.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+
+			var policyUpdate event.GenericEvent
+			Expect(policyUpdates).To(Receive(&policyUpdate))
+			Expect(policyUpdate.Object.GetName()).To(Equal(kyvernoClusterPolicy.Name))
+		})
+	})
+
+	Context("When a referenced policy is not cached", func() {
+		It("should emit a PolicyNotInCache warning event", func() {
+			policyCache.Delete(kyvernoClusterPolicy.Name)
+
+			req := ctrl.Request{
+				NamespacedName: types.NamespacedName{
+					Name:      gsPolicyException.Name,
+					Namespace: gsPolicyException.Namespace,
+				},
+			}
+			result, err := r.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(BeNumerically(">", 0))
+
+			Expect(recorder.Events).To(Receive(SatisfyAll(
+				ContainSubstring("Warning"),
+				ContainSubstring(controller.ReasonPolicyNotInCache),
+			)))
+		})
 	})
 })
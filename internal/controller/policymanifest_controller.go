@@ -19,18 +19,33 @@ package controller
 import (
 	"context"
 	"fmt"
+	"maps"
+	"slices"
 
 	policyAPI "github.com/giantswarm/policy-api/api/v1alpha1"
 	"github.com/go-logr/logr"
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	kyvernov2 "github.com/kyverno/kyverno/api/kyverno/v2"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/client-go/tools/events"
 	ctrl "sigs.k8s.io/controller-runtime"
+	ctrlbuilder "sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
+	"github.com/giantswarm/kyverno-policy-operator/internal/metrics"
+	"github.com/giantswarm/kyverno-policy-operator/internal/policycache"
 	utils "github.com/giantswarm/kyverno-policy-operator/internal/utils"
 )
 
@@ -41,23 +56,39 @@ type PolicyManifestReconciler struct {
 	Log                  logr.Logger
 	DestinationNamespace string
 	Background           bool
-	PolicyCache          map[string]kyvernov1.ClusterPolicy
+	PolicyCache          *policycache.Cache
 	MaxJitterPercent     int
+	Recorder             events.EventRecorder
+	// KindHierarchy is used to exempt the objects created by the targeted workloads. Defaults to DefaultKindHierarchy.
+	KindHierarchy KindHierarchy
+	// PolicyUpdates delivers the Kyverno policies that changed, the matching PolicyManifests are reconciled.
+	PolicyUpdates <-chan event.GenericEvent
+	// DryRun records the changes to Kyverno PolicyExceptions instead of applying them.
+	DryRun *DryRun
 }
 
-//+kubebuilder:rbac:groups=giantswarm.io,resources=policymanifests,verbs=get;list;watch;create;update;patch;delete
-//+kubebuilder:rbac:groups=giantswarm.io,resources=policymanifests/status,verbs=get;update;patch
-//+kubebuilder:rbac:groups=giantswarm.io,resources=policymanifests/finalizers,verbs=update
+//+kubebuilder:rbac:groups=policy.giantswarm.io,resources=policymanifests,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=policy.giantswarm.io,resources=policymanifests/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=policy.giantswarm.io,resources=policymanifests/finalizers,verbs=update
+//+kubebuilder:rbac:groups=kyverno.io,resources=clusterpolicies,verbs=get;list;watch;patch
+//+kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=events.k8s.io,resources=events,verbs=create;patch
 
-func (r *PolicyManifestReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+func (r *PolicyManifestReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, err error) {
 	_ = log.FromContext(ctx)
 
+	// Drop the pending dry-run changes, the reconciliation records the ones still planned. Deleted PolicyManifests leave none behind.
+	if r.DryRun != nil {
+		r.DryRun.forgetSource(dryRunSource(PolicyManifestKind, req.NamespacedName))
+	}
+
 	var polman policyAPI.PolicyManifest
 	{
 		if err := r.Get(ctx, req.NamespacedName, &polman); err != nil {
 			// Error fetching the policy manifest
 
 			if errors.IsNotFound(err) {
+				metrics.UnresolvedPolicies.DeleteLabelValues(metrics.ControllerPolicyManifest, req.Namespace, req.Name)
 				return ctrl.Result{}, nil
 			}
 
@@ -66,80 +97,436 @@ func (r *PolicyManifestReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 		}
 	}
 
-	// Check if the PolicyManifest has any exceptions defined before creation
-	if len(polman.Spec.Exceptions) == 0 && len(polman.Spec.AutomatedExceptions) == 0 {
-		// Create label selector
-		labelSelector := client.MatchingLabels{
-			GSPolicy:  polman.Labels[GSPolicy],
-			ManagedBy: ComponentName,
-		}
-		// Delete Exception
-		if err := r.DeleteAllOf(ctx, &kyvernov2.PolicyException{}, client.InNamespace(r.DestinationNamespace), labelSelector); err != nil {
-			if errors.IsNotFound(err) {
+	// Remove the generated objects before the PolicyManifest is gone
+	if !polman.DeletionTimestamp.IsZero() {
+		metrics.UnresolvedPolicies.DeleteLabelValues(metrics.ControllerPolicyManifest, polman.Namespace, polman.Name)
+
+		if controllerutil.ContainsFinalizer(&polman, Finalizer) {
+			if err := r.deletePolicyExceptions(ctx, &polman); err != nil {
+				log.Log.Error(err, fmt.Sprintf("unable to delete Kyverno PolicyExceptions for PolicyManifest %s", polman.Name))
+				r.Recorder.Eventf(&polman, nil, corev1.EventTypeWarning, ReasonKyvernoRejected, ActionDelete,
+					"Unable to delete Kyverno PolicyExceptions: %s", err)
+				return ctrl.Result{}, err
+			}
+			// Without args the ConfigMap and the context entries of the ClusterPolicy are removed
+			if _, err := r.reconcileArgs(ctx, &polman, nil, false); err != nil {
+				log.Log.Error(err, fmt.Sprintf("unable to remove args of PolicyManifest %s", polman.Name))
+				return ctrl.Result{}, err
+			}
+
+			if r.DryRun != nil {
 				return ctrl.Result{}, nil
 			}
 
-			log.Log.Error(err, fmt.Sprintf("unable to delete PolicyException for %s", polman.Name))
-			return ctrl.Result{}, nil
+			controllerutil.RemoveFinalizer(&polman, Finalizer)
+			if err := r.Update(ctx, &polman); err != nil {
+				return ctrl.Result{}, err
+			}
 		}
 
-		log.Log.Info(fmt.Sprintf("PolicyException for %s deleted", polman.Name))
+		return ctrl.Result{}, nil
+	}
+
+	// Add the finalizer so the generated objects are cleaned up, owner references alone leave the ClusterPolicy context entries behind
+	if r.DryRun == nil && controllerutil.AddFinalizer(&polman, Finalizer) {
+		if err := r.Update(ctx, &polman); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	// Fetch the current status so condition transition times are preserved
+	status := PolicyManifestStatus{}
+	if err := getStatus(ctx, r.Client, &polman, &status); err != nil {
+		log.Log.Error(err, fmt.Sprintf("unable to fetch status for PolicyManifest %s", polman.Name))
+	}
+	status.ObservedGeneration = polman.Generation
+	status.Exceptions = len(polman.Spec.Exceptions)
+	status.AutomatedExceptions = len(polman.Spec.AutomatedExceptions)
+
+	// Failures to configure the ClusterPolicy don't hold back the exceptions, they are returned once the exceptions are translated
+	var policyErrs []error
+	defer func() {
+		if len(policyErrs) > 0 {
+			result, err = ctrl.Result{}, utilerrors.NewAggregate(append(policyErrs, err))
+		}
+	}()
+
+	// The mode drives the enforcement of the ClusterPolicy, independently of the exceptions
+	status.Mode = ""
+	if enforcement, err := parseMode(polman.Spec.Mode); err != nil {
+		log.Log.Error(err, fmt.Sprintf("unable to parse mode of %s", polman.Name))
+		r.Recorder.Eventf(&polman, nil, corev1.EventTypeWarning, ReasonInvalidMode, ActionEnforce, "%s", err)
+		setConditions(&status.Conditions, status.ObservedGeneration, metav1.ConditionFalse, ReasonInvalidMode, err.Error(), ConditionModeApplied)
+	} else if enforcement == nil {
+		meta.RemoveStatusCondition(&status.Conditions, ConditionModeApplied)
+	} else if state, err := r.reconcileMode(ctx, &polman, enforcement, hasConditionReason(status.Conditions, ConditionModeApplied, ReasonModeDrifted)); err != nil {
+		log.Log.Error(err, fmt.Sprintf("unable to apply mode %s to ClusterPolicy %s", polman.Spec.Mode, polman.Name))
+		r.Recorder.Eventf(&polman, nil, corev1.EventTypeWarning, ReasonModeFailed, ActionEnforce,
+			"Unable to apply mode %s to ClusterPolicy %s: %s", polman.Spec.Mode, polman.Name, err)
+		setConditions(&status.Conditions, status.ObservedGeneration, metav1.ConditionFalse, ReasonModeFailed, err.Error(), ConditionModeApplied)
+		policyErrs = append(policyErrs, err)
+	} else {
+		switch state {
+		case modeApplied:
+			status.Mode = polman.Spec.Mode
+			message := fmt.Sprintf("ClusterPolicy %s is set to mode %s", polman.Name, polman.Spec.Mode)
+			setConditions(&status.Conditions, status.ObservedGeneration, metav1.ConditionTrue, ReasonModeApplied, message, ConditionModeApplied)
+		case modeDrifted:
+			message := fmt.Sprintf("ClusterPolicy %s was changed by another manager, mode %s is not applied again until it changes", polman.Name, polman.Spec.Mode)
+			setConditions(&status.Conditions, status.ObservedGeneration, metav1.ConditionFalse, ReasonModeDrifted, message, ConditionModeApplied)
+		default:
+			// The ClusterPolicy doesn't exist yet, the mode is applied once it is created
+			meta.RemoveStatusCondition(&status.Conditions, ConditionModeApplied)
+		}
+	}
+
+	// The args parameterize the ClusterPolicy through a context variable
+	if args, err := parseArgs(polman.Spec.Args); err != nil {
+		log.Log.Error(err, fmt.Sprintf("unable to parse args of %s", polman.Name))
+		r.Recorder.Eventf(&polman, nil, corev1.EventTypeWarning, ReasonInvalidArgs, ActionTranslate, "%s", err)
+		setConditions(&status.Conditions, status.ObservedGeneration, metav1.ConditionFalse, ReasonInvalidArgs, err.Error(), ConditionArgsApplied)
+	} else if drifted, err := r.reconcileArgs(ctx, &polman, args, hasConditionReason(status.Conditions, ConditionArgsApplied, ReasonArgsDrifted)); err != nil {
+		log.Log.Error(err, fmt.Sprintf("unable to apply args of %s", polman.Name))
+		r.Recorder.Eventf(&polman, nil, corev1.EventTypeWarning, ReasonArgsFailed, ActionTranslate,
+			"Unable to apply args to ClusterPolicy %s: %s", polman.Name, err)
+		setConditions(&status.Conditions, status.ObservedGeneration, metav1.ConditionFalse, ReasonArgsFailed, err.Error(), ConditionArgsApplied)
+		policyErrs = append(policyErrs, err)
+	} else if drifted {
+		message := fmt.Sprintf("ClusterPolicy %s was changed by another manager, the %s context is not added again", polman.Name, ArgsContextName)
+		setConditions(&status.Conditions, status.ObservedGeneration, metav1.ConditionFalse, ReasonArgsDrifted, message, ConditionArgsApplied)
+	} else if len(args) > 0 {
+		message := fmt.Sprintf("%d args are written to ConfigMap %s/%s", len(args), r.DestinationNamespace, argsConfigMapName(&polman))
+		setConditions(&status.Conditions, status.ObservedGeneration, metav1.ConditionTrue, ReasonArgsApplied, message, ConditionArgsApplied)
+	} else {
+		meta.RemoveStatusCondition(&status.Conditions, ConditionArgsApplied)
+	}
+
+	// Targets matched by labels are added to the name-based targets
+	selectorTargets, err := parseSelectorTargets(polman.Annotations)
+	if err != nil {
+		log.Log.Error(err, fmt.Sprintf("unable to parse selector targets for %s", polman.Name))
+		r.Recorder.Eventf(&polman, nil, corev1.EventTypeWarning, ReasonInvalidSelectorTargets, ActionTranslate, "%s", err)
+		metrics.TranslationFailures.WithLabelValues(metrics.ControllerPolicyManifest, ReasonInvalidSelectorTargets).Inc()
+
+		setConditions(&status.Conditions, status.ObservedGeneration, metav1.ConditionFalse, ReasonInvalidSelectorTargets, err.Error(), ConditionTranslated, ConditionReady)
+		r.updateStatus(ctx, &polman, status)
+
+		return utils.JitterRequeue(DefaultRequeueDuration, r.MaxJitterPercent, r.Log), nil
+	}
+
+	// Targets without names would exempt every object of their kind
+	err = checkTargetNames(polman.Spec.Exceptions, "exceptions")
+	if err == nil {
+		err = checkTargetNames(polman.Spec.AutomatedExceptions, "automatedExceptions")
+	}
+	if err != nil {
+		log.Log.Error(err, fmt.Sprintf("unable to translate targets for %s", polman.Name))
+		r.Recorder.Eventf(&polman, nil, corev1.EventTypeWarning, ReasonInvalidTargets, ActionTranslate, "%s", err)
+		metrics.TranslationFailures.WithLabelValues(metrics.ControllerPolicyManifest, ReasonInvalidTargets).Inc()
+
+		setConditions(&status.Conditions, status.ObservedGeneration, metav1.ConditionFalse, ReasonInvalidTargets, err.Error(), ConditionTranslated, ConditionReady)
+		r.updateStatus(ctx, &polman, status)
 
-		// Exit since there are no exceptions
 		return utils.JitterRequeue(DefaultRequeueDuration, r.MaxJitterPercent, r.Log), nil
 	}
 
-	kyvernoPolicyException := kyvernov2.PolicyException{}
-	// Set kyvernoPolicyException destination namespace.
-	kyvernoPolicyException.Namespace = r.DestinationNamespace
-	// Set kyvernoPolicyException name.
-	kyvernoPolicyException.Name = fmt.Sprintf("gs-kpo-%s-exceptions", polman.Name)
-	// Set labels.
-	kyvernoPolicyException.Labels = generateLabels()
-	kyvernoPolicyException.Labels[GSPolicy] = polman.Labels[GSPolicy]
+	// Check if the PolicyManifest has any exceptions defined before creation
+	if len(polman.Spec.Exceptions) == 0 && len(polman.Spec.AutomatedExceptions) == 0 && len(selectorTargets) == 0 {
+		// Only the Kyverno PolicyExceptions generated from this PolicyManifest are deleted, each one is reported
+		if err := r.deletePolicyExceptions(ctx, &polman); err != nil {
+			log.Log.Error(err, fmt.Sprintf("unable to delete PolicyException for %s", polman.Name))
+			r.Recorder.Eventf(&polman, nil, corev1.EventTypeWarning, ReasonKyvernoRejected, ActionDelete,
+				"Unable to delete Kyverno PolicyException for %s: %s", polman.Name, err)
 
-	kyvernoPolicyException.Spec.Background = &r.Background
+			setConditions(&status.Conditions, status.ObservedGeneration, metav1.ConditionFalse, ReasonKyvernoRejected, err.Error(), ConditionReady)
+			r.updateStatus(ctx, &polman, status)
 
-	allTargets := make([]policyAPI.Target, len(polman.Spec.Exceptions)+len(polman.Spec.AutomatedExceptions))
-	copy(allTargets, polman.Spec.Exceptions)
-	copy(allTargets[len(polman.Spec.Exceptions):], polman.Spec.AutomatedExceptions)
+			return utils.JitterRequeue(DefaultRequeueDuration, r.MaxJitterPercent, r.Log), nil
+		}
 
-	var kyvernoPolicy kyvernov1.ClusterPolicy
+		meta.RemoveStatusCondition(&status.Conditions, ConditionTranslated)
+		setConditions(&status.Conditions, status.ObservedGeneration, metav1.ConditionTrue, ReasonNoExceptions, "PolicyManifest has no exceptions", ConditionReady)
+		status.KyvernoPolicyException = nil
+		status.AutomatedKyvernoPolicyException = nil
+		r.updateStatus(ctx, &polman, status)
+
+		// Exit since there are no exceptions
+		return utils.JitterRequeue(DefaultRequeueDuration, r.MaxJitterPercent, r.Log), nil
+	}
+
+	var kyvernoPolicy kyvernov1.PolicyInterface
 	var ok bool
 
-	if kyvernoPolicy, ok = r.PolicyCache[polman.Name]; !ok {
+	if kyvernoPolicy, ok = r.PolicyCache.Get(polman.Name); !ok {
 		log.Log.Error(fmt.Errorf("policy %s not found in cache", polman.Name), "unable to fetch Kyverno Policy from cache")
-		return ctrl.Result{Requeue: true}, nil
+		r.Recorder.Eventf(&polman, nil, corev1.EventTypeWarning, ReasonPolicyNotInCache, ActionTranslate,
+			"Policy %s not found in cache", polman.Name)
+		metrics.PolicyCacheMisses.WithLabelValues(metrics.ControllerPolicyManifest).Inc()
+		metrics.UnresolvedPolicies.WithLabelValues(metrics.ControllerPolicyManifest, polman.Namespace, polman.Name).Set(1)
+
+		message := fmt.Sprintf("ClusterPolicy %s not found in cache", polman.Name)
+		setConditions(&status.Conditions, status.ObservedGeneration, metav1.ConditionFalse, ReasonPolicyNotInCache, message, ConditionPolicyResolved, ConditionReady)
+		r.updateStatus(ctx, &polman, status)
+
+		// The PolicyManifest is reconciled again as soon as the ClusterPolicy is cached
+		return utils.JitterRequeue(DefaultRequeueDuration, r.MaxJitterPercent, r.Log), nil
+	}
+	metrics.UnresolvedPolicies.WithLabelValues(metrics.ControllerPolicyManifest, polman.Namespace, polman.Name).Set(0)
+	setConditions(&status.Conditions, status.ObservedGeneration, metav1.ConditionTrue, ReasonPoliciesResolved, fmt.Sprintf("ClusterPolicy %s was found", polman.Name), ConditionPolicyResolved)
+
+	policies := []kyvernov1.PolicyInterface{kyvernoPolicy}
+
+	// Pod Security Standards controls narrow the exception down to the validate.podSecurity rules
+	podSecurity, err := parsePodSecurity(polman.Annotations)
+	if err == nil && podSecurity != nil {
+		err = checkPodSecurityRules(policies)
+	}
+	if err != nil {
+		log.Log.Error(err, fmt.Sprintf("unable to translate pod security controls for %s", polman.Name))
+		r.Recorder.Eventf(&polman, nil, corev1.EventTypeWarning, ReasonInvalidPodSecurity, ActionTranslate, "%s", err)
+		metrics.TranslationFailures.WithLabelValues(metrics.ControllerPolicyManifest, ReasonInvalidPodSecurity).Inc()
+
+		setConditions(&status.Conditions, status.ObservedGeneration, metav1.ConditionFalse, ReasonInvalidPodSecurity, err.Error(), ConditionTranslated, ConditionReady)
+		r.updateStatus(ctx, &polman, status)
+
+		return utils.JitterRequeue(DefaultRequeueDuration, r.MaxJitterPercent, r.Log), nil
+	}
+
+	ruleSelection, err := parseRuleSelection(polman.Annotations)
+	if err != nil {
+		log.Log.Error(err, fmt.Sprintf("unable to parse rule selection for %s", polman.Name))
+		r.Recorder.Eventf(&polman, nil, corev1.EventTypeWarning, ReasonInvalidRuleSelection, ActionTranslate, "%s", err)
+		metrics.TranslationFailures.WithLabelValues(metrics.ControllerPolicyManifest, ReasonInvalidRuleSelection).Inc()
+
+		setConditions(&status.Conditions, status.ObservedGeneration, metav1.ConditionFalse, ReasonInvalidRuleSelection, err.Error(), ConditionTranslated, ConditionReady)
+		r.updateStatus(ctx, &polman, status)
+
+		return utils.JitterRequeue(DefaultRequeueDuration, r.MaxJitterPercent, r.Log), nil
+	}
+
+	newExceptions, err := translatePoliciesToExceptions(policies, ruleSelection, podSecurity != nil)
+	if err != nil {
+		log.Log.Error(err, fmt.Sprintf("unable to translate policy for %s", polman.Name))
+		r.Recorder.Eventf(&polman, nil, corev1.EventTypeWarning, ReasonInvalidRuleSelection, ActionTranslate, "%s", err)
+		metrics.TranslationFailures.WithLabelValues(metrics.ControllerPolicyManifest, ReasonInvalidRuleSelection).Inc()
+
+		setConditions(&status.Conditions, status.ObservedGeneration, metav1.ConditionFalse, ReasonInvalidRuleSelection, err.Error(), ConditionTranslated, ConditionReady)
+		r.updateStatus(ctx, &polman, status)
+
+		return utils.JitterRequeue(DefaultRequeueDuration, r.MaxJitterPercent, r.Log), nil
 	}
 
-	policies := []kyvernov1.ClusterPolicy{kyvernoPolicy}
+	nameMatching, err := parseNameMatching(polman.Annotations, len(polman.Spec.Exceptions)+len(polman.Spec.AutomatedExceptions))
+	if err != nil {
+		log.Log.Error(err, fmt.Sprintf("unable to parse name matching for %s", polman.Name))
+		r.Recorder.Eventf(&polman, nil, corev1.EventTypeWarning, ReasonInvalidNameMatching, ActionTranslate, "%s", err)
+		metrics.TranslationFailures.WithLabelValues(metrics.ControllerPolicyManifest, ReasonInvalidNameMatching).Inc()
 
-	newExceptions := translatePoliciesToExceptions(policies)
+		setConditions(&status.Conditions, status.ObservedGeneration, metav1.ConditionFalse, ReasonInvalidNameMatching, err.Error(), ConditionTranslated, ConditionReady)
+		r.updateStatus(ctx, &polman, status)
 
-	// create or update a Kyverno PolicyException.
+		return utils.JitterRequeue(DefaultRequeueDuration, r.MaxJitterPercent, r.Log), nil
+	}
 
-	if op, err := controllerutil.CreateOrUpdate(ctx, r.Client, &kyvernoPolicyException, func() error {
+	conditions, err := parseConditions(polman.Annotations)
+	if err != nil {
+		log.Log.Error(err, fmt.Sprintf("unable to parse conditions for %s", polman.Name))
+		r.Recorder.Eventf(&polman, nil, corev1.EventTypeWarning, ReasonInvalidConditions, ActionTranslate, "%s", err)
+		metrics.TranslationFailures.WithLabelValues(metrics.ControllerPolicyManifest, ReasonInvalidConditions).Inc()
 
-		kyvernoPolicyException.Spec.Match.Any = translateTargetsToResourceFilters(allTargets)
+		setConditions(&status.Conditions, status.ObservedGeneration, metav1.ConditionFalse, ReasonInvalidConditions, err.Error(), ConditionTranslated, ConditionReady)
+		r.updateStatus(ctx, &polman, status)
 
-		kyvernoPolicyException.Spec.Exceptions = newExceptions
+		return utils.JitterRequeue(DefaultRequeueDuration, r.MaxJitterPercent, r.Log), nil
+	}
 
-		return nil
-	}); err != nil {
-		log.Log.Error(err, fmt.Sprintf("Reconciliation failed for PolicyException %s", kyvernoPolicyException.Name))
+	translationHash, err := sourceHash(polman.Spec, polman.Annotations)
+	if err != nil {
 		return ctrl.Result{}, err
-	} else {
-		log.Log.Info(fmt.Sprintf("PolicyException %s: %s", kyvernoPolicyException.Name, op))
 	}
 
+	// Manual and automated exceptions are written to separate Kyverno PolicyExceptions,
+	// so they can be audited and removed independently. The name matching indexes span both lists.
+	exceptionSets := []struct {
+		exceptionType   string
+		name            string
+		resourceFilters kyvernov1.ResourceFilters
+		reference       **KyvernoPolicyExceptionReference
+	}{
+		{
+			exceptionType: ExceptionTypeManual,
+			name:          fmt.Sprintf("gs-kpo-%s-exceptions", polman.Name),
+			resourceFilters: append(translateTargetsToResourceFilters(polman.Spec.Exceptions, r.KindHierarchy, nameMatching),
+				translateSelectorTargetsToResourceFilters(selectorTargets, r.KindHierarchy)...),
+			reference: &status.KyvernoPolicyException,
+		},
+		{
+			exceptionType:   ExceptionTypeAutomated,
+			name:            fmt.Sprintf("gs-kpo-%s-automated-exceptions", polman.Name),
+			resourceFilters: translateTargetsToResourceFilters(polman.Spec.AutomatedExceptions, r.KindHierarchy, nameMatching.shift(len(polman.Spec.Exceptions))),
+			reference:       &status.AutomatedKyvernoPolicyException,
+		},
+	}
+
+	var kyvernoPolicyExceptions []*kyvernov2.PolicyException
+	for _, exceptionSet := range exceptionSets {
+		*exceptionSet.reference = nil
+		if len(exceptionSet.resourceFilters) == 0 {
+			continue
+		}
+
+		kyvernoPolicyException := kyvernov2.PolicyException{}
+		// Set kyvernoPolicyException destination namespace.
+		kyvernoPolicyException.Namespace = r.DestinationNamespace
+		// Set kyvernoPolicyException name.
+		kyvernoPolicyException.Name = exceptionSet.name
+		kyvernoPolicyException.Spec.Background = &r.Background
+
+		// create or update a Kyverno PolicyException.
+
+		if op, err := createOrUpdatePolicyException(ctx, r.Client, r.DryRun, dryRunSource(PolicyManifestKind, client.ObjectKeyFromObject(&polman)), &kyvernoPolicyException, func() error {
+
+			// Set labels, the policy label follows the PolicyManifest
+			if kyvernoPolicyException.Labels == nil {
+				kyvernoPolicyException.Labels = make(map[string]string)
+			}
+			maps.Copy(kyvernoPolicyException.Labels, generateLabels())
+			kyvernoPolicyException.Labels[GSPolicy] = polman.Labels[GSPolicy]
+			kyvernoPolicyException.Labels[ExceptionType] = exceptionSet.exceptionType
+			setSourceMetadata(&kyvernoPolicyException, &polman, PolicyManifestKind, translationHash)
+
+			// The PolicyManifest is cluster scoped, so it can own the Kyverno PolicyException in any namespace
+			if err := controllerutil.SetControllerReference(&polman, &kyvernoPolicyException, r.Scheme); err != nil {
+				return err
+			}
+
+			kyvernoPolicyException.Spec.Match.Any = exceptionSet.resourceFilters
+
+			kyvernoPolicyException.Spec.Conditions = conditions
+
+			kyvernoPolicyException.Spec.PodSecurity = podSecurity
+
+			kyvernoPolicyException.Spec.Exceptions = newExceptions
+
+			return nil
+		}); err != nil {
+			log.Log.Error(err, fmt.Sprintf("Reconciliation failed for PolicyException %s", kyvernoPolicyException.Name))
+			r.Recorder.Eventf(&polman, &kyvernoPolicyException, corev1.EventTypeWarning, ReasonKyvernoRejected, ActionTranslate,
+				"Kyverno PolicyException %s/%s was rejected: %s", kyvernoPolicyException.Namespace, kyvernoPolicyException.Name, err)
+			metrics.TranslationFailures.WithLabelValues(metrics.ControllerPolicyManifest, ReasonKyvernoRejected).Inc()
+
+			setConditions(&status.Conditions, status.ObservedGeneration, metav1.ConditionFalse, ReasonKyvernoRejected, err.Error(), ConditionTranslated, ConditionReady)
+			r.updateStatus(ctx, &polman, status)
+
+			return ctrl.Result{}, err
+		} else {
+			log.Log.Info(fmt.Sprintf("PolicyException %s: %s", kyvernoPolicyException.Name, op))
+			recordOperationEvent(r.Recorder, &polman, &kyvernoPolicyException, op)
+		}
+
+		kyvernoPolicyExceptions = append(kyvernoPolicyExceptions, &kyvernoPolicyException)
+		*exceptionSet.reference = &KyvernoPolicyExceptionReference{
+			Name:      kyvernoPolicyException.Name,
+			Namespace: kyvernoPolicyException.Namespace,
+		}
+	}
+
+	// Remove the Kyverno PolicyExceptions left in a previous destination namespace, or of a type without exceptions anymore
+	if err := r.deletePolicyExceptions(ctx, &polman, kyvernoPolicyExceptions...); err != nil {
+		log.Log.Error(err, fmt.Sprintf("unable to delete stale Kyverno PolicyExceptions for PolicyManifest %s", polman.Name))
+		return ctrl.Result{}, err
+	}
+
+	message := fmt.Sprintf("%d Kyverno PolicyExceptions in %s are up to date", len(kyvernoPolicyExceptions), r.DestinationNamespace)
+	setConditions(&status.Conditions, status.ObservedGeneration, metav1.ConditionTrue, ReasonReconciled, message, ConditionTranslated, ConditionReady)
+	r.updateStatus(ctx, &polman, status)
+
 	return utils.JitterRequeue(DefaultRequeueDuration, r.MaxJitterPercent, r.Log), nil
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *PolicyManifestReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewControllerManagedBy(mgr).
+	if err := indexPolicyManifestPolicy(context.Background(), mgr); err != nil {
+		return err
+	}
+
+	builder := ctrl.NewControllerManagedBy(mgr).
 		// Uncomment the following line adding a pointer to an instance of the controlled resource as an argument
 		// For().
-		For(&policyAPI.PolicyManifest{}).
-		Complete(r)
+		// The status carries the last reconcile time, so status updates must not trigger reconciliations
+		For(&policyAPI.PolicyManifest{}, ctrlbuilder.WithPredicates(predicate.Or(
+			predicate.GenerationChangedPredicate{},
+			predicate.AnnotationChangedPredicate{},
+			predicate.LabelChangedPredicate{},
+		))).
+		// Recreate the Kyverno PolicyException when it's deleted or changed by hand
+		Owns(&kyvernov2.PolicyException{})
+
+	if r.PolicyUpdates != nil {
+		builder = builder.WatchesRawSource(source.Channel(r.PolicyUpdates, handler.EnqueueRequestsFromMapFunc(r.mapPolicyToManifests)))
+	}
+
+	return builder.Complete(r)
+}
+
+// updateStatus writes status, stamped with the reconcile time, to the PolicyManifest. Nothing is written in dry-run mode.
+// Failures are logged and reported but don't fail the reconciliation, e.g. when the installed CRD lacks the status subresource.
+func (r *PolicyManifestReconciler) updateStatus(ctx context.Context, polman *policyAPI.PolicyManifest, status PolicyManifestStatus) {
+	if r.DryRun != nil {
+		return
+	}
+
+	now := metav1.Now()
+	status.LastReconcileTime = &now
+	if err := patchStatus(ctx, r.Client, polman, status); err != nil {
+		reportStatusUpdateFailure(r.Recorder, polman, err)
+	}
+}
+
+// deletePolicyExceptions removes the Kyverno PolicyExceptions generated from polman in any namespace, except the ones in keep.
+// PolicyExceptions created before source tracking was introduced are found by name in the destination namespace.
+// Deletions not scoped to polman are refused and reported.
+func (r *PolicyManifestReconciler) deletePolicyExceptions(ctx context.Context, polman *policyAPI.PolicyManifest, keep ...*kyvernov2.PolicyException) (err error) {
+	defer func() { reportBroadDelete(r.Recorder, polman, metrics.ControllerPolicyManifest, err) }()
+
+	selector, err := ownedSelector(polman)
+	if err != nil {
+		return err
+	}
+
+	var policyExceptions kyvernov2.PolicyExceptionList
+	if err := r.List(ctx, &policyExceptions, selector); err != nil {
+		return err
+	}
+
+	legacyName := fmt.Sprintf("gs-kpo-%s-exceptions", polman.Name)
+	legacyPolicyException := kyvernov2.PolicyException{}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: r.DestinationNamespace, Name: legacyName}, &legacyPolicyException); err == nil {
+		if legacyPolicyException.Labels[SourceUID] == "" && legacyPolicyException.Labels[ManagedBy] == ComponentName {
+			policyExceptions.Items = append(policyExceptions.Items, legacyPolicyException)
+		}
+	} else if !errors.IsNotFound(err) {
+		return err
+	}
+
+	for i := range policyExceptions.Items {
+		policyException := &policyExceptions.Items[i]
+		if slices.ContainsFunc(keep, func(kept *kyvernov2.PolicyException) bool {
+			return client.ObjectKeyFromObject(policyException) == client.ObjectKeyFromObject(kept)
+		}) {
+			continue
+		}
+		if err := checkOwned(policyException, polman, legacyName); err != nil {
+			return err
+		}
+
+		if err := deletePolicyException(ctx, r.Client, r.DryRun, dryRunSource(PolicyManifestKind, client.ObjectKeyFromObject(polman)), policyException); err != nil {
+			return err
+		}
+		log.Log.Info(fmt.Sprintf("PolicyException %s deleted", client.ObjectKeyFromObject(policyException)))
+		r.Recorder.Eventf(polman, policyException, corev1.EventTypeNormal, ReasonDeleted, ActionDelete,
+			"Kyverno PolicyException %s deleted", client.ObjectKeyFromObject(policyException))
+	}
+
+	return nil
 }
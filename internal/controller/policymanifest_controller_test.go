@@ -19,21 +19,29 @@ package controller_test
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	policyAPI "github.com/giantswarm/policy-api/api/v1alpha1"
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	kyvernov2 "github.com/kyverno/kyverno/api/kyverno/v2"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
 	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/events"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 
 	"github.com/giantswarm/kyverno-policy-operator/internal/controller"
+	"github.com/giantswarm/kyverno-policy-operator/internal/policycache"
 )
 
 var _ = Describe("PolicyManifest Controller", func() {
@@ -42,13 +50,13 @@ var _ = Describe("PolicyManifest Controller", func() {
 		kyvernoClusterPolicy   kyvernov1.ClusterPolicy
 		gsPolicyManifest       policyAPI.PolicyManifest
 		r                      *controller.PolicyManifestReconciler
-		policyCache            map[string]kyvernov1.ClusterPolicy
+		policyCache            *policycache.Cache
 		kyvernoPolicyException kyvernov2.PolicyException
 	)
 
 	BeforeEach(func() {
 		// initialize the shared PolicyCache
-		policyCache = make(map[string]kyvernov1.ClusterPolicy)
+		policyCache = policycache.New()
 
 		// Initialize the Policy Manifest Reconciler
 		r = &controller.PolicyManifestReconciler{
@@ -59,6 +67,7 @@ var _ = Describe("PolicyManifest Controller", func() {
 			Background:           false,
 			PolicyCache:          policyCache,
 			MaxJitterPercent:     maxJitterPercent,
+			Recorder:             events.NewFakeRecorder(100),
 		}
 
 		logger := zap.New(zap.WriteTo(GinkgoWriter), zap.UseDevMode(true))
@@ -150,6 +159,7 @@ var _ = Describe("PolicyManifest Controller", func() {
 			Log:              logger,
 			PolicyCache:      policyCache,
 			MaxJitterPercent: maxJitterPercent,
+			Recorder:         events.NewFakeRecorder(100),
 		}
 		req := ctrl.Request{
 			NamespacedName: types.NamespacedName{
@@ -170,7 +180,11 @@ var _ = Describe("PolicyManifest Controller", func() {
 	AfterEach(func() {
 		// Clean up the Kyverno Cluster Policy and the Giant Swarm Policy Manifest
 		Expect(k8sClient.Delete(ctx, &kyvernoClusterPolicy)).Should(Succeed())
-		Expect(k8sClient.Delete(ctx, &gsPolicyManifest)).Should(Succeed())
+		Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, &gsPolicyManifest))).Should(Succeed())
+
+		// Reconcile the deletion so the finalizer is removed
+		_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(&gsPolicyManifest)})
+		Expect(err).NotTo(HaveOccurred())
 	})
 
 	Context("When successfully reconciling a PolicyManifest", func() {
@@ -182,7 +196,7 @@ var _ = Describe("PolicyManifest Controller", func() {
 				},
 			}
 
-			Expect(policyCache).NotTo(BeEmpty())
+			Expect(policyCache.Len()).NotTo(BeZero())
 
 			// Test for a successful reconciliation
 			result, err := r.Reconcile(ctx, req)
@@ -207,9 +221,516 @@ var _ = Describe("PolicyManifest Controller", func() {
 			//Expect(kyvernoPolicyException.Spec.Match.Any[1].Kinds).To(ConsistOf("Pod"))
 			Expect(kyvernoPolicyException.Spec.Exceptions[0].PolicyName).To(Equal("disallow-privileged-containers"))
 			Expect(kyvernoPolicyException.Spec.Exceptions[0].RuleNames[0]).To(Equal("restrict-privileged-containers"))
-			Expect(kyvernoPolicyException.Spec.Match.Any[0].ResourceDescription.Names[0]).To(Equal("test-app-1*"))
+			// Names are matched by ownership, the Deployment itself is matched exactly
+			Expect(kyvernoPolicyException.Spec.Match.Any[0].ResourceDescription.Names[0]).To(Equal("test-app-1"))
 			//Expect(kyvernoPolicyException.Spec.Match.Any[0].ResourceDescription.Names[1]).To(Equal("test-app-2*"))
 			Expect(kyvernoPolicyException.Spec.Match.Any[0].ResourceDescription.Namespaces[0]).To(Equal("default"))
 		})
 	})
+
+	Context("When the PolicyManifest sets a mode", func() {
+		It("should apply the mode to the ClusterPolicy and stop once another manager changes it", func() {
+			req := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(&gsPolicyManifest)}
+			_, err := r.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(&kyvernoClusterPolicy), &kyvernoClusterPolicy)).To(Succeed())
+			Expect(kyvernoClusterPolicy.Spec.ValidationFailureAction).To(Equal(kyvernov1.Enforce))
+			Expect(kyvernoClusterPolicy.Annotations).To(HaveKeyWithValue(controller.AppliedModeAnnotation, controller.ModeEnforce))
+
+			// Drop the events of the first reconciliation
+			recorder := r.Recorder.(*events.FakeRecorder)
+			for len(recorder.Events) > 0 {
+				<-recorder.Events
+			}
+
+			// The owner of the ClusterPolicy, e.g. Helm, reverts it to Audit
+			kyvernoClusterPolicy.Spec.ValidationFailureAction = kyvernov1.Audit
+			Expect(k8sClient.Update(ctx, &kyvernoClusterPolicy)).To(Succeed())
+
+			_, err = r.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(&kyvernoClusterPolicy), &kyvernoClusterPolicy)).To(Succeed())
+			Expect(kyvernoClusterPolicy.Spec.ValidationFailureAction).To(Equal(kyvernov1.Audit))
+			Expect(recorder.Events).To(Receive(ContainSubstring(controller.ReasonModeDrifted)))
+
+			reconciled := unstructured.Unstructured{}
+			reconciled.SetGroupVersionKind(policyAPI.GroupVersion.WithKind(controller.PolicyManifestKind))
+			Expect(k8sClient.Get(ctx, req.NamespacedName, &reconciled)).To(Succeed())
+			mode, _, err := unstructured.NestedString(reconciled.Object, "status", "mode")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(mode).To(BeEmpty())
+			conditions, _, err := unstructured.NestedSlice(reconciled.Object, "status", "conditions")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(conditions).To(ContainElement(SatisfyAll(
+				HaveKeyWithValue("type", controller.ConditionModeApplied),
+				HaveKeyWithValue("status", "False"),
+				HaveKeyWithValue("reason", controller.ReasonModeDrifted),
+			)))
+
+			// The drift is reported once and the ClusterPolicy is left alone
+			for len(recorder.Events) > 0 {
+				<-recorder.Events
+			}
+			_, err = r.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(&kyvernoClusterPolicy), &kyvernoClusterPolicy)).To(Succeed())
+			Expect(kyvernoClusterPolicy.Spec.ValidationFailureAction).To(Equal(kyvernov1.Audit))
+			Expect(recorder.Events).NotTo(Receive(ContainSubstring(controller.ReasonModeDrifted)))
+
+			// A new mode is applied again
+			gsPolicyManifest.Spec.Mode = controller.ModeWarming
+			Expect(k8sClient.Update(ctx, &gsPolicyManifest)).To(Succeed())
+			_, err = r.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(&kyvernoClusterPolicy), &kyvernoClusterPolicy)).To(Succeed())
+			Expect(kyvernoClusterPolicy.Spec.EmitWarning).To(HaveValue(BeTrue()))
+		})
+
+		It("should audit and emit warnings in warming mode", func() {
+			gsPolicyManifest.Spec.Mode = controller.ModeWarming
+			Expect(k8sClient.Update(ctx, &gsPolicyManifest)).To(Succeed())
+
+			req := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(&gsPolicyManifest)}
+			_, err := r.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(&kyvernoClusterPolicy), &kyvernoClusterPolicy)).To(Succeed())
+			Expect(kyvernoClusterPolicy.Spec.ValidationFailureAction).To(Equal(kyvernov1.Audit))
+			Expect(kyvernoClusterPolicy.Spec.EmitWarning).To(HaveValue(BeTrue()))
+
+			// Both the PolicyManifest and the ClusterPolicy are warned, the ModeApplied event comes first
+			recorder := r.Recorder.(*events.FakeRecorder)
+			var warnings []string
+			for len(recorder.Events) > 0 {
+				if event := <-recorder.Events; strings.Contains(event, controller.ReasonWarming) {
+					warnings = append(warnings, event)
+				}
+			}
+			Expect(warnings).To(ConsistOf(
+				HavePrefix(corev1.EventTypeWarning+" "+controller.ReasonWarming+" ClusterPolicy "+kyvernoClusterPolicy.Name),
+				HavePrefix(corev1.EventTypeWarning+" "+controller.ReasonWarming+" Warming up for PolicyManifest "+gsPolicyManifest.Name),
+			))
+
+			// The events are only emitted when the mode is applied
+			_, err = r.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(recorder.Events).NotTo(Receive(ContainSubstring(controller.ReasonWarming)))
+		})
+		It("should translate the exceptions when the mode can't be applied", func() {
+			// Reject the patches setting the mode, everything else reaches the API server
+			withWatch, err := client.NewWithWatch(cfg, client.Options{Scheme: scheme.Scheme})
+			Expect(err).NotTo(HaveOccurred())
+			r.Client = interceptor.NewClient(withWatch, interceptor.Funcs{
+				Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+					data, err := patch.Data(obj)
+					if err != nil {
+						return err
+					}
+					if _, ok := obj.(*kyvernov1.ClusterPolicy); ok && strings.Contains(string(data), controller.AppliedModeAnnotation) {
+						return fmt.Errorf("patch rejected")
+					}
+					return c.Patch(ctx, obj, patch, opts...)
+				},
+			})
+			defer func() { r.Client = k8sClient }()
+
+			req := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(&gsPolicyManifest)}
+			_, err = r.Reconcile(ctx, req)
+			Expect(err).To(MatchError(ContainSubstring("patch rejected")))
+			Expect(r.Recorder.(*events.FakeRecorder).Events).To(Receive(HavePrefix(corev1.EventTypeWarning + " " + controller.ReasonModeFailed)))
+
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: "default", Name: fmt.Sprintf("gs-kpo-%s-exceptions", gsPolicyManifest.Name)}, &kyvernoPolicyException)).To(Succeed())
+
+			reconciled := unstructured.Unstructured{}
+			reconciled.SetGroupVersionKind(policyAPI.GroupVersion.WithKind(controller.PolicyManifestKind))
+			Expect(k8sClient.Get(ctx, req.NamespacedName, &reconciled)).To(Succeed())
+
+			conditions, _, err := unstructured.NestedSlice(reconciled.Object, "status", "conditions")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(conditions).To(ContainElement(SatisfyAll(
+				HaveKeyWithValue("type", controller.ConditionModeApplied),
+				HaveKeyWithValue("status", "False"),
+				HaveKeyWithValue("reason", controller.ReasonModeFailed),
+			)))
+			Expect(conditions).To(ContainElement(SatisfyAll(
+				HaveKeyWithValue("type", controller.ConditionTranslated),
+				HaveKeyWithValue("status", "True"),
+			)))
+		})
+	})
+
+	Context("When the PolicyManifest sets args", func() {
+		It("should write the args to a ConfigMap referenced by every rule", func() {
+			gsPolicyManifest.Spec.Args = []string{"--allowed-registries=ghcr.io/giantswarm/*", "--test-arg-1"}
+			Expect(k8sClient.Update(ctx, &gsPolicyManifest)).To(Succeed())
+
+			req := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(&gsPolicyManifest)}
+			_, err := r.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+
+			configMap := corev1.ConfigMap{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: "default", Name: "gs-kpo-disallow-privileged-containers-args"}, &configMap)).To(Succeed())
+			Expect(configMap.Data).To(Equal(map[string]string{
+				"allowed-registries": "ghcr.io/giantswarm/*",
+				"test-arg-1":         "true",
+			}))
+
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(&kyvernoClusterPolicy), &kyvernoClusterPolicy)).To(Succeed())
+			Expect(kyvernoClusterPolicy.Spec.Rules[0].Context).To(ContainElement(kyvernov1.ContextEntry{
+				Name:      controller.ArgsContextName,
+				ConfigMap: &kyvernov1.ConfigMapReference{Name: configMap.Name, Namespace: configMap.Namespace},
+			}))
+			Expect(kyvernoClusterPolicy.Annotations).To(HaveKeyWithValue(controller.AppliedArgsAnnotation, "default/gs-kpo-disallow-privileged-containers-args"))
+
+			// Without args the ConfigMap and the context entry are removed
+			gsPolicyManifest.Spec.Args = []string{}
+			Expect(k8sClient.Update(ctx, &gsPolicyManifest)).To(Succeed())
+			_, err = r.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = k8sClient.Get(ctx, client.ObjectKeyFromObject(&configMap), &configMap)
+			Expect(apierrors.IsNotFound(err)).To(BeTrue())
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(&kyvernoClusterPolicy), &kyvernoClusterPolicy)).To(Succeed())
+			Expect(kyvernoClusterPolicy.Spec.Rules[0].Context).To(BeEmpty())
+			Expect(kyvernoClusterPolicy.Annotations).NotTo(HaveKey(controller.AppliedArgsAnnotation))
+		})
+
+		It("should translate the exceptions when the args can't be applied", func() {
+			// Reject the patches adding the args context, everything else reaches the API server
+			withWatch, err := client.NewWithWatch(cfg, client.Options{Scheme: scheme.Scheme})
+			Expect(err).NotTo(HaveOccurred())
+			r.Client = interceptor.NewClient(withWatch, interceptor.Funcs{
+				Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+					data, err := patch.Data(obj)
+					if err != nil {
+						return err
+					}
+					if _, ok := obj.(*kyvernov1.ClusterPolicy); ok && strings.Contains(string(data), controller.ArgsContextName) {
+						return fmt.Errorf("patch rejected")
+					}
+					return c.Patch(ctx, obj, patch, opts...)
+				},
+			})
+			defer func() { r.Client = k8sClient }()
+
+			req := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(&gsPolicyManifest)}
+			_, err = r.Reconcile(ctx, req)
+			Expect(err).To(MatchError(ContainSubstring("patch rejected")))
+			Eventually(r.Recorder.(*events.FakeRecorder).Events).Should(Receive(HavePrefix(corev1.EventTypeWarning + " " + controller.ReasonArgsFailed)))
+
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: "default", Name: fmt.Sprintf("gs-kpo-%s-exceptions", gsPolicyManifest.Name)}, &kyvernoPolicyException)).To(Succeed())
+
+			reconciled := unstructured.Unstructured{}
+			reconciled.SetGroupVersionKind(policyAPI.GroupVersion.WithKind(controller.PolicyManifestKind))
+			Expect(k8sClient.Get(ctx, req.NamespacedName, &reconciled)).To(Succeed())
+
+			conditions, _, err := unstructured.NestedSlice(reconciled.Object, "status", "conditions")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(conditions).To(ContainElement(SatisfyAll(
+				HaveKeyWithValue("type", controller.ConditionArgsApplied),
+				HaveKeyWithValue("status", "False"),
+				HaveKeyWithValue("reason", controller.ReasonArgsFailed),
+			)))
+			Expect(conditions).To(ContainElement(SatisfyAll(
+				HaveKeyWithValue("type", controller.ConditionTranslated),
+				HaveKeyWithValue("status", "True"),
+			)))
+
+			// The ClusterPolicy doesn't record the ConfigMap, so the cleanup leaves it behind
+			configMap := corev1.ConfigMap{}
+			configMap.Namespace, configMap.Name = "default", "gs-kpo-disallow-privileged-containers-args"
+			Expect(k8sClient.Delete(ctx, &configMap)).To(Succeed())
+		})
+
+		It("should leave context entries it didn't add", func() {
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(&kyvernoClusterPolicy), &kyvernoClusterPolicy)).To(Succeed())
+			authorEntry := kyvernov1.ContextEntry{
+				Name:      controller.ArgsContextName,
+				ConfigMap: &kyvernov1.ConfigMapReference{Name: "policy-defaults", Namespace: "kyverno"},
+			}
+			kyvernoClusterPolicy.Spec.Rules[0].Context = []kyvernov1.ContextEntry{authorEntry}
+			Expect(k8sClient.Update(ctx, &kyvernoClusterPolicy)).To(Succeed())
+
+			gsPolicyManifest.Spec.Args = nil
+			Expect(k8sClient.Update(ctx, &gsPolicyManifest)).To(Succeed())
+
+			req := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(&gsPolicyManifest)}
+			_, err := r.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(&kyvernoClusterPolicy), &kyvernoClusterPolicy)).To(Succeed())
+			Expect(kyvernoClusterPolicy.Spec.Rules[0].Context).To(ConsistOf(authorEntry))
+			Expect(kyvernoClusterPolicy.Annotations).NotTo(HaveKey(controller.AppliedArgsAnnotation))
+		})
+
+		It("should not take over context entries it didn't add", func() {
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(&kyvernoClusterPolicy), &kyvernoClusterPolicy)).To(Succeed())
+			authorEntry := kyvernov1.ContextEntry{
+				Name:      controller.ArgsContextName,
+				ConfigMap: &kyvernov1.ConfigMapReference{Name: "policy-defaults", Namespace: "kyverno"},
+			}
+			kyvernoClusterPolicy.Spec.Rules[0].Context = []kyvernov1.ContextEntry{authorEntry}
+			Expect(k8sClient.Update(ctx, &kyvernoClusterPolicy)).To(Succeed())
+
+			req := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(&gsPolicyManifest)}
+			_, err := r.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(&kyvernoClusterPolicy), &kyvernoClusterPolicy)).To(Succeed())
+			Expect(kyvernoClusterPolicy.Spec.Rules[0].Context).To(ConsistOf(authorEntry))
+			Eventually(r.Recorder.(*events.FakeRecorder).Events).Should(Receive(SatisfyAll(
+				HavePrefix(corev1.EventTypeWarning+" "+controller.ReasonArgsConflict),
+				ContainSubstring(kyvernoClusterPolicy.Spec.Rules[0].Name),
+			)))
+
+			// Removing the args keeps the entry of the policy authors
+			gsPolicyManifest.Spec.Args = nil
+			Expect(k8sClient.Update(ctx, &gsPolicyManifest)).To(Succeed())
+			_, err = r.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(&kyvernoClusterPolicy), &kyvernoClusterPolicy)).To(Succeed())
+			Expect(kyvernoClusterPolicy.Spec.Rules[0].Context).To(ConsistOf(authorEntry))
+			Expect(kyvernoClusterPolicy.Annotations).NotTo(HaveKey(controller.AppliedArgsAnnotation))
+		})
+
+		It("should not add the args context again once another manager removed it", func() {
+			req := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(&gsPolicyManifest)}
+			_, err := r.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(&kyvernoClusterPolicy), &kyvernoClusterPolicy)).To(Succeed())
+			Expect(kyvernoClusterPolicy.Spec.Rules[0].Context).NotTo(BeEmpty())
+
+			// The owner of the ClusterPolicy, e.g. Helm, reverts the rules
+			kyvernoClusterPolicy.Spec.Rules[0].Context = nil
+			Expect(k8sClient.Update(ctx, &kyvernoClusterPolicy)).To(Succeed())
+
+			recorder := r.Recorder.(*events.FakeRecorder)
+			for len(recorder.Events) > 0 {
+				<-recorder.Events
+			}
+			_, err = r.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(&kyvernoClusterPolicy), &kyvernoClusterPolicy)).To(Succeed())
+			Expect(kyvernoClusterPolicy.Spec.Rules[0].Context).To(BeEmpty())
+			Eventually(recorder.Events).Should(Receive(HavePrefix(corev1.EventTypeWarning + " " + controller.ReasonArgsDrifted)))
+
+			reconciled := unstructured.Unstructured{}
+			reconciled.SetGroupVersionKind(policyAPI.GroupVersion.WithKind(controller.PolicyManifestKind))
+			Expect(k8sClient.Get(ctx, req.NamespacedName, &reconciled)).To(Succeed())
+			conditions, _, err := unstructured.NestedSlice(reconciled.Object, "status", "conditions")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(conditions).To(ContainElement(SatisfyAll(
+				HaveKeyWithValue("type", controller.ConditionArgsApplied),
+				HaveKeyWithValue("status", "False"),
+				HaveKeyWithValue("reason", controller.ReasonArgsDrifted),
+			)))
+
+			// The drift is reported once and the ClusterPolicy is left alone
+			for len(recorder.Events) > 0 {
+				<-recorder.Events
+			}
+			_, err = r.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(&kyvernoClusterPolicy), &kyvernoClusterPolicy)).To(Succeed())
+			Expect(kyvernoClusterPolicy.Spec.Rules[0].Context).To(BeEmpty())
+			Expect(recorder.Events).NotTo(Receive(ContainSubstring(controller.ReasonArgsDrifted)))
+		})
+
+		It("should report args which can't be parsed", func() {
+			gsPolicyManifest.Spec.Args = []string{"--allowed registries=ghcr.io"}
+			Expect(k8sClient.Update(ctx, &gsPolicyManifest)).To(Succeed())
+
+			req := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(&gsPolicyManifest)}
+			_, err := r.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+
+			// The mode is applied first, so earlier events are skipped
+			Eventually(r.Recorder.(*events.FakeRecorder).Events).Should(Receive(ContainSubstring(controller.ReasonInvalidArgs)))
+		})
+	})
+
+	Context("When the PolicyManifest is deleted", func() {
+		It("should delete the Kyverno Policy Exception and the args ConfigMap", func() {
+			req := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(&gsPolicyManifest)}
+			_, err := r.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(k8sClient.Get(ctx, req.NamespacedName, &gsPolicyManifest)).To(Succeed())
+			Expect(gsPolicyManifest.Finalizers).To(ContainElement(controller.Finalizer))
+
+			policyExceptionKey := types.NamespacedName{Namespace: "default", Name: fmt.Sprintf("gs-kpo-%s-exceptions", gsPolicyManifest.Name)}
+			Expect(k8sClient.Get(ctx, policyExceptionKey, &kyvernoPolicyException)).To(Succeed())
+			Expect(metav1.IsControlledBy(&kyvernoPolicyException, &gsPolicyManifest)).To(BeTrue())
+
+			Expect(k8sClient.Delete(ctx, &gsPolicyManifest)).To(Succeed())
+			_, err = r.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = k8sClient.Get(ctx, policyExceptionKey, &kyvernoPolicyException)
+			Expect(apierrors.IsNotFound(err)).To(BeTrue())
+			err = k8sClient.Get(ctx, types.NamespacedName{Namespace: "default", Name: fmt.Sprintf("gs-kpo-%s-args", gsPolicyManifest.Name)}, &corev1.ConfigMap{})
+			Expect(apierrors.IsNotFound(err)).To(BeTrue())
+			err = k8sClient.Get(ctx, req.NamespacedName, &gsPolicyManifest)
+			Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		})
+	})
+
+	Context("When the PolicyManifest has manual and automated exceptions", func() {
+		It("should generate separate Kyverno Policy Exceptions and remove the automated one independently", func() {
+			req := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(&gsPolicyManifest)}
+			_, err := r.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+
+			manualKey := types.NamespacedName{Namespace: "default", Name: fmt.Sprintf("gs-kpo-%s-exceptions", gsPolicyManifest.Name)}
+			automatedKey := types.NamespacedName{Namespace: "default", Name: fmt.Sprintf("gs-kpo-%s-automated-exceptions", gsPolicyManifest.Name)}
+
+			Expect(k8sClient.Get(ctx, manualKey, &kyvernoPolicyException)).To(Succeed())
+			Expect(kyvernoPolicyException.Labels).To(HaveKeyWithValue(controller.ExceptionType, controller.ExceptionTypeManual))
+			Expect(kyvernoPolicyException.Spec.Match.Any).To(HaveLen(1))
+			Expect(kyvernoPolicyException.Spec.Match.Any[0].ResourceDescription.Names).To(ContainElement("test-app-1"))
+
+			automatedPolicyException := kyvernov2.PolicyException{}
+			Expect(k8sClient.Get(ctx, automatedKey, &automatedPolicyException)).To(Succeed())
+			Expect(automatedPolicyException.Labels).To(HaveKeyWithValue(controller.ExceptionType, controller.ExceptionTypeAutomated))
+			Expect(automatedPolicyException.Labels).To(HaveKeyWithValue(controller.SourceUID, string(gsPolicyManifest.UID)))
+			Expect(automatedPolicyException.Spec.Match.Any).To(HaveLen(1))
+			Expect(automatedPolicyException.Spec.Match.Any[0].ResourceDescription.Names).To(ContainElement("test-app-2"))
+
+			// Wiping the automated exceptions leaves the manual ones untouched
+			Expect(k8sClient.Get(ctx, req.NamespacedName, &gsPolicyManifest)).To(Succeed())
+			gsPolicyManifest.Spec.AutomatedExceptions = []policyAPI.Target{}
+			Expect(k8sClient.Update(ctx, &gsPolicyManifest)).To(Succeed())
+
+			_, err = r.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = k8sClient.Get(ctx, automatedKey, &automatedPolicyException)
+			Expect(apierrors.IsNotFound(err)).To(BeTrue())
+			Expect(k8sClient.Get(ctx, manualKey, &kyvernoPolicyException)).To(Succeed())
+		})
+	})
+
+	Context("When the PolicyManifest has no exceptions", func() {
+		It("should only delete the Kyverno Policy Exception it generated", func() {
+			req := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(&gsPolicyManifest)}
+			_, err := r.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+
+			// A Kyverno PolicyException translated from a Giant Swarm PolicyException carries the same empty policy label
+			otherPolicyException := kyvernov2.PolicyException{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "other-policyexception",
+					Namespace: "default",
+					Labels: map[string]string{
+						controller.ManagedBy: controller.ComponentName,
+						controller.GSPolicy:  "",
+						controller.SourceUID: "other-uid",
+					},
+				},
+				Spec: kyvernov2.PolicyExceptionSpec{
+					Exceptions: []kyvernov2.Exception{{PolicyName: "disallow-privileged-containers", RuleNames: []string{"restrict-privileged-containers"}}},
+					Match:      kyvernov2.MatchResources{Any: kyvernov1.ResourceFilters{{ResourceDescription: kyvernov1.ResourceDescription{Kinds: []string{"Pod"}}}}},
+				},
+			}
+			Expect(k8sClient.Create(ctx, &otherPolicyException)).To(Succeed())
+			defer func() {
+				Expect(k8sClient.Delete(ctx, &otherPolicyException)).To(Succeed())
+			}()
+
+			Expect(k8sClient.Get(ctx, req.NamespacedName, &gsPolicyManifest)).To(Succeed())
+			gsPolicyManifest.Spec.Exceptions = []policyAPI.Target{}
+			gsPolicyManifest.Spec.AutomatedExceptions = []policyAPI.Target{}
+			Expect(k8sClient.Update(ctx, &gsPolicyManifest)).To(Succeed())
+
+			_, err = r.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = k8sClient.Get(ctx, types.NamespacedName{Namespace: "default", Name: fmt.Sprintf("gs-kpo-%s-exceptions", gsPolicyManifest.Name)}, &kyvernoPolicyException)
+			Expect(apierrors.IsNotFound(err)).To(BeTrue())
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(&otherPolicyException), &otherPolicyException)).To(Succeed())
+		})
+	})
+
+	Context("When reporting the PolicyManifest status", func() {
+		It("should report the targets, the generated Kyverno Policy Exception and the mode", func() {
+			req := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(&gsPolicyManifest)}
+			_, err := r.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+
+			// The Policy API types do not carry a status, so we read it as unstructured.
+			reconciled := unstructured.Unstructured{}
+			reconciled.SetGroupVersionKind(policyAPI.GroupVersion.WithKind(controller.PolicyManifestKind))
+			Expect(k8sClient.Get(ctx, req.NamespacedName, &reconciled)).To(Succeed())
+
+			status, found, err := unstructured.NestedMap(reconciled.Object, "status")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(status).To(HaveKeyWithValue("exceptions", BeEquivalentTo(1)))
+			Expect(status).To(HaveKeyWithValue("automatedExceptions", BeEquivalentTo(1)))
+			Expect(status).To(HaveKeyWithValue("mode", controller.ModeEnforce))
+			Expect(status).To(HaveKey("lastReconcileTime"))
+			Expect(status).To(HaveKeyWithValue("kyvernoPolicyException", SatisfyAll(
+				HaveKeyWithValue("name", fmt.Sprintf("gs-kpo-%s-exceptions", gsPolicyManifest.Name)),
+				HaveKeyWithValue("namespace", "default"),
+			)))
+			Expect(status).To(HaveKeyWithValue("automatedKyvernoPolicyException", SatisfyAll(
+				HaveKeyWithValue("name", fmt.Sprintf("gs-kpo-%s-automated-exceptions", gsPolicyManifest.Name)),
+				HaveKeyWithValue("namespace", "default"),
+			)))
+			Expect(status["conditions"]).To(ContainElement(SatisfyAll(
+				HaveKeyWithValue("type", controller.ConditionPolicyResolved),
+				HaveKeyWithValue("status", "True"),
+			)))
+			Expect(status["conditions"]).To(ContainElement(SatisfyAll(
+				HaveKeyWithValue("type", controller.ConditionReady),
+				HaveKeyWithValue("status", "True"),
+			)))
+		})
+
+		It("should only report the mode once it was applied to the ClusterPolicy", func() {
+			Expect(k8sClient.Delete(ctx, &kyvernoClusterPolicy)).To(Succeed())
+
+			req := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(&gsPolicyManifest)}
+			_, err := r.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+
+			reconciled := unstructured.Unstructured{}
+			reconciled.SetGroupVersionKind(policyAPI.GroupVersion.WithKind(controller.PolicyManifestKind))
+			Expect(k8sClient.Get(ctx, req.NamespacedName, &reconciled)).To(Succeed())
+
+			mode, _, err := unstructured.NestedString(reconciled.Object, "status", "mode")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(mode).To(BeEmpty())
+
+			// Recreate the ClusterPolicy for the cleanup
+			kyvernoClusterPolicy.ResourceVersion = ""
+			Expect(k8sClient.Create(ctx, &kyvernoClusterPolicy)).To(Succeed())
+		})
+
+		It("should report a missing ClusterPolicy", func() {
+			policyCache.Delete(kyvernoClusterPolicy.Name)
+
+			req := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(&gsPolicyManifest)}
+			_, err := r.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+
+			reconciled := unstructured.Unstructured{}
+			reconciled.SetGroupVersionKind(policyAPI.GroupVersion.WithKind(controller.PolicyManifestKind))
+			Expect(k8sClient.Get(ctx, req.NamespacedName, &reconciled)).To(Succeed())
+
+			conditions, found, err := unstructured.NestedSlice(reconciled.Object, "status", "conditions")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(conditions).To(ContainElement(SatisfyAll(
+				HaveKeyWithValue("type", controller.ConditionPolicyResolved),
+				HaveKeyWithValue("status", "False"),
+				HaveKeyWithValue("reason", controller.ReasonPolicyNotInCache),
+			)))
+		})
+	})
 })
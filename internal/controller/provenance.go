@@ -0,0 +1,68 @@
+package controller
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"slices"
+	"strconv"
+	"strings"
+
+	kyvernov2 "github.com/kyverno/kyverno/api/kyverno/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Annotations which record metadata about a source object rather than changing its translation.
+var provenanceAnnotations = []string{ApprovedSpecHashAnnotation, ApprovedByAnnotation, CreatedByAnnotation}
+
+// sourceHash returns the hash of a source spec and the annotations changing its translation.
+// Generated Kyverno PolicyExceptions carry it as their translation hash.
+func sourceHash(spec any, sourceAnnotations map[string]string) (string, error) {
+	annotations := make(map[string]string)
+	for key, value := range sourceAnnotations {
+		if strings.HasPrefix(key, AnnotationPrefix) && !slices.Contains(provenanceAnnotations, key) {
+			annotations[key] = value
+		}
+	}
+
+	// Map keys are sorted by encoding/json, so the hash is stable
+	data, err := json.Marshal(struct {
+		Spec        any               `json:"spec"`
+		Annotations map[string]string `json:"annotations"`
+	}{
+		Spec:        spec,
+		Annotations: annotations,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:]), nil
+}
+
+// setSourceMetadata labels and annotates a generated Kyverno PolicyException with the object it was translated from,
+// so auditors can trace it back to the source and the request which caused it.
+func setSourceMetadata(policyException *kyvernov2.PolicyException, source client.Object, kind, translationHash string) {
+	if policyException.Labels == nil {
+		policyException.Labels = make(map[string]string)
+	}
+	policyException.Labels[SourceUID] = string(source.GetUID())
+
+	if policyException.Annotations == nil {
+		policyException.Annotations = make(map[string]string)
+	}
+	policyException.Annotations[SourceKind] = kind
+	policyException.Annotations[SourceNamespace] = source.GetNamespace()
+	policyException.Annotations[SourceName] = source.GetName()
+	policyException.Annotations[SourceUID] = string(source.GetUID())
+	policyException.Annotations[SourceGeneration] = strconv.FormatInt(source.GetGeneration(), 10)
+	policyException.Annotations[TranslationHash] = translationHash
+
+	// The field managers aren't used as a fallback, they name client tools rather than users and change with every writer
+	if createdBy := source.GetAnnotations()[CreatedByAnnotation]; createdBy != "" {
+		policyException.Annotations[CreatedByAnnotation] = createdBy
+	} else {
+		delete(policyException.Annotations, CreatedByAnnotation)
+	}
+}
@@ -0,0 +1,209 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/events"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// Condition types reported on Giant Swarm PolicyExceptions.
+const (
+	// ConditionReady is True when the generated Kyverno PolicyException is in effect.
+	ConditionReady = "Ready"
+	// ConditionPolicyResolved is True when every referenced policy was found in the PolicyCache.
+	ConditionPolicyResolved = "PolicyResolved"
+	// ConditionTranslated is True when the Kyverno PolicyException was written successfully.
+	ConditionTranslated = "Translated"
+	// ConditionExpired is True once the expires-at deadline has passed and the Kyverno PolicyException was removed.
+	ConditionExpired = "Expired"
+	// ConditionExpiringSoon is True during the ExpiryWarningWindow before the expires-at deadline.
+	ConditionExpiringSoon = "ExpiringSoon"
+	// ConditionApproved is True when an approver accepted the current spec. Only reported when approval is required.
+	ConditionApproved = "Approved"
+	// ConditionModeApplied is True when the ClusterPolicy is set to the PolicyManifest mode. Only reported when a mode is set.
+	ConditionModeApplied = "ModeApplied"
+	// ConditionArgsApplied is True when the PolicyManifest args were written to their ConfigMap and ClusterPolicy. Only reported when args are set.
+	ConditionArgsApplied = "ArgsApplied"
+)
+
+// Reasons used in conditions and events reported on the reconciled objects.
+const (
+	ReasonReconciled       = "Reconciled"
+	ReasonPoliciesResolved = "PoliciesResolved"
+	ReasonPolicyNotInCache = "PolicyNotInCache"
+	// ReasonPartiallyResolved is reported when the resolvable policies were translated and others are still missing.
+	ReasonPartiallyResolved     = "PartiallyResolved"
+	ReasonInvalidResolutionMode = "InvalidResolutionMode"
+	ReasonKyvernoRejected       = "KyvernoRejected"
+	// ReasonInvalidRuleSelection is reported when the rules annotation names unknown policies or rules.
+	ReasonInvalidRuleSelection = "InvalidRuleSelection"
+	// ReasonInvalidSelectorTargets is reported when the selector-targets annotation can't be parsed.
+	ReasonInvalidSelectorTargets = "InvalidSelectorTargets"
+	// ReasonInvalidPodSecurity is reported when the pod-security annotation is invalid or the policies have no validate.podSecurity rules.
+	ReasonInvalidPodSecurity = "InvalidPodSecurity"
+	// ReasonInvalidConditions is reported when the conditions annotation can't be parsed or uses unsupported operators.
+	ReasonInvalidConditions = "InvalidConditions"
+	// ReasonInvalidMode is reported when the PolicyManifest mode is unknown.
+	ReasonInvalidMode = "InvalidMode"
+	// ReasonModeApplied is reported when the PolicyManifest mode was applied to its ClusterPolicy.
+	ReasonModeApplied = "ModeApplied"
+	// ReasonModeFailed is reported when the PolicyManifest mode couldn't be applied to its ClusterPolicy.
+	ReasonModeFailed = "ModeFailed"
+	// ReasonWarming is reported when the warming mode was applied to a ClusterPolicy, its violations are only audited.
+	ReasonWarming = "Warming"
+	// ReasonModeDrifted is reported when another manager changed the enforcement of a ClusterPolicy, the mode is not applied again.
+	ReasonModeDrifted = "ModeDrifted"
+	// ReasonInvalidArgs is reported when the PolicyManifest args can't be parsed.
+	ReasonInvalidArgs = "InvalidArgs"
+	// ReasonArgsApplied is reported when the ConfigMap holding the PolicyManifest args was written.
+	ReasonArgsApplied = "ArgsApplied"
+	// ReasonArgsFailed is reported when the PolicyManifest args couldn't be written to their ConfigMap or ClusterPolicy.
+	ReasonArgsFailed = "ArgsFailed"
+	// ReasonArgsConflict is reported when rules of a ClusterPolicy define their own args context entry, which the operator leaves alone.
+	ReasonArgsConflict = "ArgsConflict"
+	// ReasonArgsDrifted is reported when another manager removed the args context entries from a ClusterPolicy, they are not added again.
+	ReasonArgsDrifted = "ArgsDrifted"
+	// ReasonBroadDeleteRefused is reported when a deletion would have removed Kyverno PolicyExceptions of other sources.
+	ReasonBroadDeleteRefused = "BroadDeleteRefused"
+	// ReasonStatusUpdateFailed is reported when the status can't be written. CRDs without the status subresource are not reported.
+	ReasonStatusUpdateFailed = "StatusUpdateFailed"
+	// ReasonInvalidTargets is reported when a target has no names, so it would match every object of its kind.
+	ReasonInvalidTargets = "InvalidTargets"
+	// ReasonInvalidNameMatching is reported when the name-matching annotation can't be parsed.
+	ReasonInvalidNameMatching = "InvalidNameMatching"
+	ReasonNoExceptions        = "NoExceptions"
+	ReasonExpired             = "Expired"
+	ReasonNotExpired          = "NotExpired"
+	ReasonExpiringSoon        = "ExpiringSoon"
+	ReasonInvalidExpiry       = "InvalidExpiry"
+	ReasonPendingApproval     = "PendingApproval"
+	ReasonApproved            = "Approved"
+	ReasonCreated             = "Created"
+	ReasonUpdated             = "Updated"
+	ReasonDeleted             = "Deleted"
+)
+
+// PolicyExceptionStatus is the observed state written to the status subresource of a Giant Swarm PolicyException.
+type PolicyExceptionStatus struct {
+	// ObservedGeneration is the generation of the PolicyException that was last reconciled.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Conditions describe the current state of the translation.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// KyvernoPolicyException references the generated Kyverno PolicyException.
+	// Not omitted when empty so the merge patch clears a stale reference.
+	KyvernoPolicyException *KyvernoPolicyExceptionReference `json:"kyvernoPolicyException"`
+	// UnresolvedPolicies lists the referenced policies which were not found in the PolicyCache.
+	// Not omitted when empty so the merge patch clears the list once every policy is resolved.
+	UnresolvedPolicies []string `json:"unresolvedPolicies"`
+	// SpecHash is the hash approvers set in the approved-spec-hash annotation to approve the current spec.
+	// Not omitted when empty so the merge patch clears it once approval is no longer required.
+	SpecHash string `json:"specHash"`
+}
+
+// PolicyManifestStatus is the observed state written to the status subresource of a PolicyManifest.
+type PolicyManifestStatus struct {
+	// ObservedGeneration is the generation of the PolicyManifest that was last reconciled.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Conditions describe whether the ClusterPolicy was found and the state of the translation.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// Exceptions is the number of manual exception targets.
+	Exceptions int `json:"exceptions"`
+	// AutomatedExceptions is the number of automated exception targets.
+	AutomatedExceptions int `json:"automatedExceptions"`
+	// KyvernoPolicyException references the Kyverno PolicyException generated from the manual exceptions.
+	// Not omitted when empty so the merge patch clears a stale reference.
+	KyvernoPolicyException *KyvernoPolicyExceptionReference `json:"kyvernoPolicyException"`
+	// AutomatedKyvernoPolicyException references the Kyverno PolicyException generated from the automated exceptions.
+	AutomatedKyvernoPolicyException *KyvernoPolicyExceptionReference `json:"automatedKyvernoPolicyException"`
+	// Mode is the mode applied to the ClusterPolicy, empty when the mode is unset or invalid,
+	// or when the ClusterPolicy doesn't exist yet.
+	Mode string `json:"mode"`
+	// LastReconcileTime is when the PolicyManifest was last reconciled.
+	LastReconcileTime *metav1.Time `json:"lastReconcileTime,omitempty"`
+}
+
+// KyvernoPolicyExceptionReference points to a Kyverno PolicyException generated by the operator.
+type KyvernoPolicyExceptionReference struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+}
+
+// getStatus reads the status subresource of obj into status.
+// The Policy API types do not carry a status field, so the object is fetched as unstructured.
+func getStatus(ctx context.Context, c client.Client, obj client.Object, status any) error {
+	gvk, err := apiutil.GVKForObject(obj, c.Scheme())
+	if err != nil {
+		return err
+	}
+
+	existingObj := unstructured.Unstructured{}
+	existingObj.SetGroupVersionKind(gvk)
+	if err := c.Get(ctx, client.ObjectKeyFromObject(obj), &existingObj); err != nil {
+		return err
+	}
+
+	rawStatus, found, err := unstructured.NestedFieldNoCopy(existingObj.Object, "status")
+	if err != nil || !found {
+		return err
+	}
+
+	data, err := json.Marshal(rawStatus)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, status)
+}
+
+// patchStatus writes status to the status subresource of obj using a JSON merge patch.
+func patchStatus(ctx context.Context, c client.Client, obj client.Object, status any) error {
+	data, err := json.Marshal(map[string]any{"status": status})
+	if err != nil {
+		return err
+	}
+
+	return c.Status().Patch(ctx, obj, client.RawPatch(types.MergePatchType, data))
+}
+
+// reportStatusUpdateFailure logs a failed status write and emits a Warning event on obj.
+// CRDs without the status subresource, e.g. the ones installed by policy-api, answer with NotFound. The status is skipped then,
+// the translation doesn't depend on it.
+func reportStatusUpdateFailure(recorder events.EventRecorder, obj client.Object, err error) {
+	if errors.IsNotFound(err) {
+		log.Log.V(1).Info(fmt.Sprintf("skipping status of %s, the CRD has no status subresource", client.ObjectKeyFromObject(obj)))
+		return
+	}
+
+	log.Log.Error(err, fmt.Sprintf("unable to update status for %s", client.ObjectKeyFromObject(obj)))
+	recorder.Eventf(obj, nil, corev1.EventTypeWarning, ReasonStatusUpdateFailed, ActionTranslate, "Unable to update status: %s", err)
+}
+
+// setConditions sets the given condition types to the same status, reason and message.
+func setConditions(conditions *[]metav1.Condition, observedGeneration int64, status metav1.ConditionStatus, reason, message string, conditionTypes ...string) {
+	for _, conditionType := range conditionTypes {
+		meta.SetStatusCondition(conditions, metav1.Condition{
+			Type:               conditionType,
+			Status:             status,
+			ObservedGeneration: observedGeneration,
+			Reason:             reason,
+			Message:            message,
+		})
+	}
+}
+
+// hasConditionReason reports whether the condition of conditionType is set with reason.
+func hasConditionReason(conditions []metav1.Condition, conditionType, reason string) bool {
+	condition := meta.FindStatusCondition(conditions, conditionType)
+	return condition != nil && condition.Reason == reason
+}
@@ -1,20 +1,55 @@
 package controller
 
 import (
+	"fmt"
+	"slices"
+	"strings"
 	"time"
 
 	policyAPI "github.com/giantswarm/policy-api/api/v1alpha1"
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	kyvernov2 "github.com/kyverno/kyverno/api/kyverno/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
 )
 
 var DefaultRequeueDuration = (time.Minute * 5)
 
+// ExpiryWarningWindow is how long before the expires-at deadline warning events are emitted.
+var ExpiryWarningWindow = (time.Hour * 72)
+
 const (
 	ComponentName = "kyverno-policy-operator"
 	ManagedBy     = "app.kubernetes.io/managed-by"
 	GSPolicy      = "policy.giantswarm.io/policy"
 	MaxNameLength = 58
+
+	// Finalizer is set on source objects so their Kyverno PolicyExceptions are removed in any namespace.
+	Finalizer = "policy.giantswarm.io/kyverno-policy-operator"
+
+	// SourceUID labels and annotates generated Kyverno PolicyExceptions with the UID of the object they were translated from.
+	SourceUID = "policy.giantswarm.io/source-uid"
+	// Annotations pointing generated Kyverno PolicyExceptions back to the object they were translated from.
+	SourceKind       = "policy.giantswarm.io/source-kind"
+	SourceNamespace  = "policy.giantswarm.io/source-namespace"
+	SourceName       = "policy.giantswarm.io/source-name"
+	SourceGeneration = "policy.giantswarm.io/source-generation"
+	// TranslationHash annotates generated Kyverno PolicyExceptions with the hash of the source spec and annotations.
+	// For Giant Swarm PolicyExceptions it matches status.specHash and the approved spec hash.
+	TranslationHash = "policy.giantswarm.io/translation-hash"
+
+	// ExceptionType labels the Kyverno PolicyExceptions generated from PolicyManifests with the kind of targets they exempt.
+	ExceptionType          = "policy.giantswarm.io/exception-type"
+	ExceptionTypeManual    = "manual"
+	ExceptionTypeAutomated = "automated"
+
+	// Kinds of the objects translated to Kyverno PolicyExceptions.
+	PolicyExceptionKind = "PolicyException"
+	PolicyManifestKind  = "PolicyManifest"
+	ClusterPolicyKind   = "ClusterPolicy"
+
+	// Prefixes Kyverno uses to name the rules it generates for Pod controllers.
+	AutogenPrefix        = "autogen-"
+	AutogenCronJobPrefix = "autogen-cronjob-"
 )
 
 // generateLabels generates the labels for the Kyverno Policy Exception.
@@ -26,15 +61,72 @@ func generateLabels() map[string]string {
 	return labels
 }
 
+// policyCacheKey returns the key of a Kyverno Policy in the PolicyCache.
+// ClusterPolicies are stored by name and namespaced Policies as <namespace>/<name>, matching the
+// policy name format of Kyverno PolicyExceptions.
+func policyCacheKey(kyvernoPolicy kyvernov1.PolicyInterface) string {
+	if kyvernoPolicy.IsNamespaced() {
+		return kyvernoPolicy.GetNamespace() + "/" + kyvernoPolicy.GetName()
+	}
+
+	return kyvernoPolicy.GetName()
+}
+
 // translateTargetsToResourceFilters takes a Giant Swarm Policy API target array and creates the necessary Kyverno ResourceFilters
-func translateTargetsToResourceFilters(targets []policyAPI.Target) kyvernov1.ResourceFilters {
+func translateTargetsToResourceFilters(targets []policyAPI.Target, kindHierarchy KindHierarchy, nameMatching NameMatching) kyvernov1.ResourceFilters {
 	resourceFilters := kyvernov1.ResourceFilters{}
-	for _, target := range targets {
+	for i, target := range targets {
+		// A ResourceFilter without names matches every object of the kind, see checkTargetNames
+		if !hasTargetNames(target) {
+			continue
+		}
+
+		switch nameMatching.modeFor(i) {
+		case NameMatchingPrefix:
+			resourceFilters = append(resourceFilters, kyvernov1.ResourceFilter{
+				ResourceDescription: kyvernov1.ResourceDescription{
+					Namespaces: target.Namespaces,
+					Names:      formatNames(target.Names),
+					Kinds:      generateExceptionKinds(target.Kind, kindHierarchy),
+				},
+			})
+		case NameMatchingExact:
+			resourceFilters = append(resourceFilters, kyvernov1.ResourceFilter{
+				ResourceDescription: kyvernov1.ResourceDescription{
+					Namespaces: target.Namespaces,
+					Names:      ownerNames(target.Names, nil),
+					Kinds:      generateExceptionKinds(target.Kind, kindHierarchy),
+				},
+			})
+		default:
+			// Every level of the hierarchy gets its own name pattern, so each kind needs its own ResourceFilter
+			levels := generateExceptionKindLevels(target.Kind, kindHierarchy)
+			segments := ownerNameSegments(levels)
+			for depth, kinds := range levels {
+				resourceFilters = append(resourceFilters, kyvernov1.ResourceFilter{
+					ResourceDescription: kyvernov1.ResourceDescription{
+						Namespaces: target.Namespaces,
+						Names:      ownerNames(target.Names, segments[:depth]),
+						Kinds:      kinds,
+					},
+				})
+			}
+		}
+	}
+	return resourceFilters
+}
+
+// translateSelectorTargetsToResourceFilters maps label-selector based targets to Kyverno ResourceFilters.
+// The selectors are matched against every generated kind, so the workload and its Pods need to carry the labels.
+func translateSelectorTargetsToResourceFilters(selectorTargets []SelectorTarget, kindHierarchy KindHierarchy) kyvernov1.ResourceFilters {
+	resourceFilters := kyvernov1.ResourceFilters{}
+	for _, selectorTarget := range selectorTargets {
 		translatedResourceFilter := kyvernov1.ResourceFilter{
 			ResourceDescription: kyvernov1.ResourceDescription{
-				Namespaces: target.Namespaces,
-				Names:      formatNames(target.Names),
-				Kinds:      generateExceptionKinds(target.Kind),
+				Namespaces:        selectorTarget.Namespaces,
+				Kinds:             generateExceptionKinds(selectorTarget.Kind, kindHierarchy),
+				Selector:          selectorTarget.Selector,
+				NamespaceSelector: selectorTarget.NamespaceSelector,
 			},
 		}
 		resourceFilters = append(resourceFilters, translatedResourceFilter)
@@ -46,6 +138,9 @@ func translateTargetsToResourceFilters(targets []policyAPI.Target) kyvernov1.Res
 func formatNames(names []string) []string {
 	newNames := []string{}
 	for _, name := range names {
+		if name == "" {
+			continue
+		}
 		// Check if name will be truncated by Kubernetes
 		if len(name) > MaxNameLength {
 			// Truncate in advanced to avoid issues
@@ -73,33 +168,110 @@ func addNameWildcard(name string) string {
 	return name
 }
 
-// translatePoliciesToExceptions takes a Kyverno ClusterPolicy array and transforms it into a Kyverno Exception array
-func translatePoliciesToExceptions(policies []kyvernov1.ClusterPolicy) []kyvernov2.Exception {
+// translatePoliciesToExceptions takes a Kyverno Policy array and transforms it into a Kyverno Exception array.
+// When ruleSelection has an entry for a policy, only the selected rules and their autogen variants are exempted.
+// When podSecurityOnly is set, only the validate.podSecurity rules are exempted, so the Pod Security Standards controls of the
+// PolicyException restrict what is skipped. See checkPodSecurityRules.
+func translatePoliciesToExceptions(policies []kyvernov1.PolicyInterface, ruleSelection map[string][]string, podSecurityOnly bool) ([]kyvernov2.Exception, error) {
 	var exceptionArray []kyvernov2.Exception
+	policyNames := make(map[string]bool)
 	for _, kyvernoPolicy := range policies {
+		policyName := policyCacheKey(kyvernoPolicy)
+		policyNames[policyName] = true
+
+		ruleNames := generatePolicyRules(kyvernoPolicy)
+		if podSecurityOnly {
+			ruleNames = generatePodSecurityRules(kyvernoPolicy)
+		}
+		if selectedRules, exists := ruleSelection[policyName]; exists {
+			var err error
+			if ruleNames, err = filterPolicyRules(ruleNames, policyName, selectedRules); err != nil {
+				return nil, err
+			}
+		}
+
 		kyvernoException := kyvernov2.Exception{
-			PolicyName: kyvernoPolicy.Name,
-			RuleNames:  generatePolicyRules(kyvernoPolicy),
+			PolicyName: policyName,
+			RuleNames:  ruleNames,
 		}
 		exceptionArray = append(exceptionArray, kyvernoException)
 	}
 
-	return exceptionArray
+	// Reject rule selections for policies which are not part of the exception
+	for policyName := range ruleSelection {
+		if !policyNames[policyName] {
+			return nil, fmt.Errorf("rules selected for policy %s which is not referenced by the exception", policyName)
+		}
+	}
+
+	return exceptionArray, nil
 }
 
 // generatePolicyRules takes a Kyverno Policy name and generates a list of rules owned by that policy
-func generatePolicyRules(kyvernoPolicy kyvernov1.ClusterPolicy) []string {
+func generatePolicyRules(kyvernoPolicy kyvernov1.PolicyInterface) []string {
 	var rulesArray []string
-	for _, rule := range kyvernoPolicy.Spec.Rules {
+	for _, rule := range kyvernoPolicy.GetSpec().Rules {
 		rulesArray = append(rulesArray, rule.Name)
 	}
-	for _, autogenRule := range kyvernoPolicy.Status.Autogen.Rules {
+	for _, autogenRule := range kyvernoPolicy.GetStatus().Autogen.Rules {
 		rulesArray = append(rulesArray, autogenRule.Name)
 	}
 
 	return rulesArray
 }
 
+// generatePodSecurityRules returns the rules of a Kyverno Policy, including autogen rules, which use validate.podSecurity.
+func generatePodSecurityRules(kyvernoPolicy kyvernov1.PolicyInterface) []string {
+	var rulesArray []string
+	for _, rule := range slices.Concat(kyvernoPolicy.GetSpec().Rules, kyvernoPolicy.GetStatus().Autogen.Rules) {
+		if rule.HasValidatePodSecurity() {
+			rulesArray = append(rulesArray, rule.Name)
+		}
+	}
+
+	return rulesArray
+}
+
+// checkPodSecurityRules rejects policies without validate.podSecurity rules, Pod Security Standards controls can't be exempted from them.
+func checkPodSecurityRules(policies []kyvernov1.PolicyInterface) error {
+	for _, kyvernoPolicy := range policies {
+		if len(generatePodSecurityRules(kyvernoPolicy)) == 0 {
+			return fmt.Errorf("policy %s has no validate.podSecurity rules to exempt controls from", policyCacheKey(kyvernoPolicy))
+		}
+	}
+
+	return nil
+}
+
+// filterPolicyRules returns the selected rules of a policy together with their matching autogen variants.
+// Selected rules which are not owned by the policy are rejected.
+func filterPolicyRules(policyRules []string, policyName string, selectedRules []string) ([]string, error) {
+	if len(selectedRules) == 0 {
+		return nil, fmt.Errorf("no rules selected for policy %s", policyName)
+	}
+
+	var rulesArray []string
+	var unknownRules []string
+	for _, selectedRule := range selectedRules {
+		if !slices.Contains(policyRules, selectedRule) {
+			unknownRules = append(unknownRules, selectedRule)
+			continue
+		}
+		// Add the rule and the autogen rules Kyverno generated from it
+		for _, ruleName := range []string{selectedRule, AutogenPrefix + selectedRule, AutogenCronJobPrefix + selectedRule} {
+			if slices.Contains(policyRules, ruleName) && !slices.Contains(rulesArray, ruleName) {
+				rulesArray = append(rulesArray, ruleName)
+			}
+		}
+	}
+
+	if len(unknownRules) != 0 {
+		return nil, fmt.Errorf("rules %s not found in policy %s", strings.Join(unknownRules, ", "), policyName)
+	}
+
+	return rulesArray, nil
+}
+
 // unorderedEqual takes two Kyverno Exception arrays and checks if they are equal even if they are not ordered the same
 func unorderedEqual(got, want []kyvernov2.Exception) bool {
 	// Check Length size first
@@ -118,6 +290,10 @@ func unorderedEqual(got, want []kyvernov2.Exception) bool {
 			// Arrays are not equals, exit
 			return false
 		} else {
+			// Check if the number of RuleNames changed, e.g. when a rule selection was widened
+			if len(exception.RuleNames) != len(exceptionMap[exception.PolicyName]) {
+				return false
+			}
 			// Check if the same RuleNames are still present in the new Exceptions
 			for _, oldRule := range exception.RuleNames {
 				found := false
@@ -140,3 +316,19 @@ func unorderedEqual(got, want []kyvernov2.Exception) bool {
 	// Arrays are equals
 	return true
 }
+
+// requeueBeforeExpiry shortens the requeue interval of result so the exception is reconciled again
+// exactly when it enters the expiry warning window and when it expires.
+func requeueBeforeExpiry(result ctrl.Result, expiresAt *time.Time) ctrl.Result {
+	if expiresAt == nil {
+		return result
+	}
+
+	for _, deadline := range []time.Time{expiresAt.Add(-ExpiryWarningWindow), *expiresAt} {
+		if until := time.Until(deadline); until > 0 && until < result.RequeueAfter {
+			result.RequeueAfter = until
+		}
+	}
+
+	return result
+}
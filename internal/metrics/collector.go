@@ -0,0 +1,74 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	kyvernov2 "github.com/kyverno/kyverno/api/kyverno/v2"
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// collectTimeout bounds the listing of Kyverno PolicyExceptions during a scrape.
+const collectTimeout = 10 * time.Second
+
+var (
+	policyExceptionsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(Namespace, "", "policy_exceptions"),
+		"Number of Kyverno PolicyExceptions managed by the operator, by destination namespace and exception type.",
+		[]string{"namespace", "type"}, nil,
+	)
+	policyExceptionsPerPolicyDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(Namespace, "", "policy_exceptions_per_policy"),
+		"Number of Kyverno PolicyExceptions managed by the operator which exempt a policy.",
+		[]string{"policy"}, nil,
+	)
+)
+
+// PolicyExceptionCollector counts the managed Kyverno PolicyExceptions at scrape time, so deleted objects never leave stale series.
+type PolicyExceptionCollector struct {
+	// Reader should be backed by the manager cache, the Kyverno PolicyExceptions are watched anyway.
+	Reader client.Reader
+	// Labels select the Kyverno PolicyExceptions managed by the operator.
+	Labels map[string]string
+	// TypeLabel is the label whose value is reported as the exception type, e.g. manual or automated.
+	TypeLabel string
+}
+
+var _ prometheus.Collector = &PolicyExceptionCollector{}
+
+// Describe implements prometheus.Collector.
+func (c *PolicyExceptionCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- policyExceptionsDesc
+	ch <- policyExceptionsPerPolicyDesc
+}
+
+// Collect implements prometheus.Collector.
+func (c *PolicyExceptionCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), collectTimeout)
+	defer cancel()
+
+	var policyExceptions kyvernov2.PolicyExceptionList
+	if err := c.Reader.List(ctx, &policyExceptions, client.MatchingLabels(c.Labels)); err != nil {
+		log.Log.Error(err, "unable to list Kyverno PolicyExceptions for metrics")
+		return
+	}
+
+	type namespaceType struct{ namespace, exceptionType string }
+	perNamespace := make(map[namespaceType]int)
+	perPolicy := make(map[string]int)
+	for _, policyException := range policyExceptions.Items {
+		perNamespace[namespaceType{policyException.Namespace, policyException.Labels[c.TypeLabel]}]++
+		for _, exception := range policyException.Spec.Exceptions {
+			perPolicy[exception.PolicyName]++
+		}
+	}
+
+	for key, count := range perNamespace {
+		ch <- prometheus.MustNewConstMetric(policyExceptionsDesc, prometheus.GaugeValue, float64(count), key.namespace, key.exceptionType)
+	}
+	for policy, count := range perPolicy {
+		ch <- prometheus.MustNewConstMetric(policyExceptionsPerPolicyDesc, prometheus.GaugeValue, float64(count), policy)
+	}
+}
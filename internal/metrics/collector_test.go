@@ -0,0 +1,58 @@
+package metrics_test
+
+import (
+	"strings"
+
+	kyvernov2 "github.com/kyverno/kyverno/api/kyverno/v2"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/giantswarm/kyverno-policy-operator/internal/metrics"
+)
+
+var _ = Describe("PolicyExceptionCollector", func() {
+	managedLabels := map[string]string{"app.kubernetes.io/managed-by": "kyverno-policy-operator"}
+	automatedLabels := map[string]string{
+		"app.kubernetes.io/managed-by":        "kyverno-policy-operator",
+		"policy.giantswarm.io/exception-type": "automated",
+	}
+
+	policyException := func(namespace, name string, labels map[string]string, policies ...string) *kyvernov2.PolicyException {
+		policyException := &kyvernov2.PolicyException{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name, Labels: labels},
+		}
+		for _, policy := range policies {
+			policyException.Spec.Exceptions = append(policyException.Spec.Exceptions, kyvernov2.Exception{PolicyName: policy})
+		}
+		return policyException
+	}
+
+	It("should count the managed Kyverno PolicyExceptions per namespace, type and policy", func() {
+		scheme := runtime.NewScheme()
+		Expect(kyvernov2.AddToScheme(scheme)).To(Succeed())
+		reader := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+			policyException("policy-exceptions", "app-a", managedLabels, "disallow-privileged-containers", "require-run-as-nonroot"),
+			policyException("policy-exceptions", "app-b", automatedLabels, "disallow-privileged-containers"),
+			policyException("tenant-a", "app-c", managedLabels, "require-run-as-nonroot"),
+			policyException("tenant-a", "unmanaged", nil, "disallow-privileged-containers"),
+		).Build()
+
+		collector := &metrics.PolicyExceptionCollector{Reader: reader, Labels: managedLabels, TypeLabel: "policy.giantswarm.io/exception-type"}
+
+		Expect(testutil.CollectAndCompare(collector, strings.NewReader(`
+# HELP kyverno_policy_operator_policy_exceptions Number of Kyverno PolicyExceptions managed by the operator, by destination namespace and exception type.
+# TYPE kyverno_policy_operator_policy_exceptions gauge
+kyverno_policy_operator_policy_exceptions{namespace="policy-exceptions",type=""} 1
+kyverno_policy_operator_policy_exceptions{namespace="policy-exceptions",type="automated"} 1
+kyverno_policy_operator_policy_exceptions{namespace="tenant-a",type=""} 1
+# HELP kyverno_policy_operator_policy_exceptions_per_policy Number of Kyverno PolicyExceptions managed by the operator which exempt a policy.
+# TYPE kyverno_policy_operator_policy_exceptions_per_policy gauge
+kyverno_policy_operator_policy_exceptions_per_policy{policy="disallow-privileged-containers"} 2
+kyverno_policy_operator_policy_exceptions_per_policy{policy="require-run-as-nonroot"} 2
+`))).To(Succeed())
+	})
+})
@@ -0,0 +1,94 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// Namespace prefixes every metric exported by the operator.
+const Namespace = "kyverno_policy_operator"
+
+// Values of the controller label.
+const (
+	ControllerPolicyException = "policyexception"
+	ControllerPolicyManifest  = "policymanifest"
+	ControllerClusterPolicy   = "clusterpolicy"
+	ControllerPolicy          = "policy"
+)
+
+// Values of the result label.
+const (
+	ResultSuccess = "success"
+	ResultFailure = "failure"
+)
+
+var (
+	// PolicyCacheSize is the number of Kyverno policies in the PolicyCache.
+	PolicyCacheSize = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: Namespace,
+		Name:      "policy_cache_size",
+		Help:      "Number of Kyverno ClusterPolicies and Policies in the policy cache.",
+	})
+
+	// PolicyCacheMisses counts the policies referenced by an exception which were not found in the PolicyCache.
+	PolicyCacheMisses = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Name:      "policy_cache_misses_total",
+		Help:      "Number of referenced Kyverno policies which were not found in the policy cache.",
+	}, []string{"controller"})
+
+	// UnresolvedPolicies is the number of policies an exception references which are currently missing.
+	UnresolvedPolicies = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: Namespace,
+		Name:      "unresolved_policies",
+		Help:      "Number of Kyverno policies referenced by a Giant Swarm PolicyException or PolicyManifest which are missing from the policy cache.",
+	}, []string{"controller", "namespace", "name"})
+
+	// TranslationFailures counts the reconciliations which couldn't produce a Kyverno PolicyException, by reason.
+	TranslationFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Name:      "translation_failures_total",
+		Help:      "Number of failed translations to Kyverno PolicyExceptions.",
+	}, []string{"controller", "reason"})
+
+	// ChartOperatorBypassUpdates counts the updates of the chart-operator bypass PolicyException.
+	ChartOperatorBypassUpdates = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Name:      "chart_operator_bypass_updates_total",
+		Help:      "Number of updates of the chart-operator bypass Kyverno PolicyException.",
+	}, []string{"controller", "result"})
+
+	// ModeDrifts counts the ClusterPolicies whose enforcement was changed by another manager after the PolicyManifest mode was applied.
+	ModeDrifts = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Name:      "mode_drifts_total",
+		Help:      "Number of times the validation failure action of a ClusterPolicy drifted from the mode of its PolicyManifest.",
+	}, []string{"policy"})
+
+	// BroadDeletesRefused counts the deletions refused because they weren't scoped to the Kyverno PolicyExceptions of a single source.
+	BroadDeletesRefused = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Name:      "broad_deletes_refused_total",
+		Help:      "Number of deletions refused because they would have removed Kyverno PolicyExceptions of other sources.",
+	}, []string{"controller"})
+
+	// DryRunPendingChanges counts the Kyverno PolicyException changes a dry-run would apply, by operation.
+	DryRunPendingChanges = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: Namespace,
+		Name:      "dry_run_pending_changes",
+		Help:      "Number of Kyverno PolicyExceptions the operator would create, update or delete if it wasn't running in dry-run mode.",
+	}, []string{"operation"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(
+		PolicyCacheSize,
+		PolicyCacheMisses,
+		UnresolvedPolicies,
+		TranslationFailures,
+		ChartOperatorBypassUpdates,
+		ModeDrifts,
+		BroadDeletesRefused,
+		DryRunPendingChanges,
+	)
+}
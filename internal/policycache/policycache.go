@@ -0,0 +1,54 @@
+package policycache
+
+import (
+	"sync"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+)
+
+// Cache holds the Kyverno policies shared by the reconcilers, keyed by name for ClusterPolicies and <namespace>/<name> for Policies.
+// It is safe for concurrent use.
+type Cache struct {
+	mu       sync.RWMutex
+	policies map[string]kyvernov1.PolicyInterface
+}
+
+// New returns an empty Cache.
+func New() *Cache {
+	return &Cache{policies: make(map[string]kyvernov1.PolicyInterface)}
+}
+
+// Get returns the policy cached under key.
+func (c *Cache) Get(key string) (kyvernov1.PolicyInterface, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	policy, ok := c.policies[key]
+	return policy, ok
+}
+
+// Set caches policy under key and returns the number of cached policies.
+func (c *Cache) Set(key string, policy kyvernov1.PolicyInterface) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.policies[key] = policy
+	return len(c.policies)
+}
+
+// Delete removes the policy cached under key and returns the number of cached policies.
+func (c *Cache) Delete(key string) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.policies, key)
+	return len(c.policies)
+}
+
+// Len returns the number of cached policies.
+func (c *Cache) Len() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return len(c.policies)
+}
@@ -0,0 +1,60 @@
+package policycache_test
+
+import (
+	"fmt"
+	"sync"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/giantswarm/kyverno-policy-operator/internal/policycache"
+)
+
+var _ = Describe("Cache", func() {
+	It("should get, set and delete policies", func() {
+		cache := policycache.New()
+		policy := &kyvernov1.ClusterPolicy{ObjectMeta: metav1.ObjectMeta{Name: "disallow-privileged-containers"}}
+
+		_, ok := cache.Get(policy.Name)
+		Expect(ok).To(BeFalse())
+
+		Expect(cache.Set(policy.Name, policy)).To(Equal(1))
+		cached, ok := cache.Get(policy.Name)
+		Expect(ok).To(BeTrue())
+		Expect(cached).To(BeIdenticalTo(policy))
+
+		Expect(cache.Delete(policy.Name)).To(Equal(0))
+		_, ok = cache.Get(policy.Name)
+		Expect(ok).To(BeFalse())
+	})
+
+	// Run with -race, the reconcilers share the cache across goroutines
+	It("should be safe for concurrent use", func() {
+		cache := policycache.New()
+
+		var wg sync.WaitGroup
+		for writer := range 4 {
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				for i := range 100 {
+					key := fmt.Sprintf("policy-%d-%d", writer, i)
+					cache.Set(key, &kyvernov1.ClusterPolicy{ObjectMeta: metav1.ObjectMeta{Name: key}})
+					cache.Delete(key)
+				}
+			}()
+			go func() {
+				defer wg.Done()
+				for i := range 100 {
+					cache.Get(fmt.Sprintf("policy-%d-%d", writer, i))
+					cache.Len()
+				}
+			}()
+		}
+		wg.Wait()
+
+		Expect(cache.Len()).To(BeZero())
+	})
+})
@@ -0,0 +1,14 @@
+package policycache_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestPolicyCache(t *testing.T) {
+	RegisterFailHandler(Fail)
+
+	RunSpecs(t, "PolicyCache Suite")
+}
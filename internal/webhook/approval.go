@@ -0,0 +1,86 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	policyAPI "github.com/giantswarm/policy-api/api/v1alpha1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/giantswarm/kyverno-policy-operator/internal/controller"
+)
+
+// serviceAccountUsernamePrefix prefixes the username of ServiceAccounts, followed by <namespace>:<name>.
+const serviceAccountUsernamePrefix = "system:serviceaccount:"
+
+// Approvers are the identities allowed to approve Giant Swarm PolicyExceptions.
+type Approvers struct {
+	// Groups lists the approver groups.
+	Groups []string
+	// ServiceAccounts lists the approver ServiceAccounts as <namespace>/<name>.
+	ServiceAccounts []string
+}
+
+// isApprover returns true when the user belongs to an approver group or is an approver ServiceAccount.
+func (a Approvers) isApprover(userInfo authenticationv1.UserInfo) bool {
+	for _, group := range userInfo.Groups {
+		if slices.Contains(a.Groups, group) {
+			return true
+		}
+	}
+
+	for _, serviceAccount := range a.ServiceAccounts {
+		namespace, name, _ := strings.Cut(serviceAccount, "/")
+		if userInfo.Username == serviceAccountUsernamePrefix+namespace+":"+name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// validateApproval checks that only approvers set the approval annotations, in their own name and for the current spec.
+// Removing an approval is always allowed.
+func (a Approvers) validateApproval(ctx context.Context, oldGSPolicyException, gsPolicyException *policyAPI.PolicyException) field.ErrorList {
+	approvedSpecHash := gsPolicyException.Annotations[controller.ApprovedSpecHashAnnotation]
+	approvedBy := gsPolicyException.Annotations[controller.ApprovedByAnnotation]
+	if approvedSpecHash == "" && approvedBy == "" {
+		return nil
+	}
+	if oldGSPolicyException != nil &&
+		oldGSPolicyException.Annotations[controller.ApprovedSpecHashAnnotation] == approvedSpecHash &&
+		oldGSPolicyException.Annotations[controller.ApprovedByAnnotation] == approvedBy {
+		return nil
+	}
+
+	annotationsPath := field.NewPath("metadata", "annotations")
+	approvedSpecHashPath := annotationsPath.Key(controller.ApprovedSpecHashAnnotation)
+	approvedByPath := annotationsPath.Key(controller.ApprovedByAnnotation)
+
+	req, err := admission.RequestFromContext(ctx)
+	if err != nil {
+		return field.ErrorList{field.InternalError(approvedSpecHashPath, err)}
+	}
+	if !a.isApprover(req.UserInfo) {
+		return field.ErrorList{field.Forbidden(approvedSpecHashPath, fmt.Sprintf("%s is not allowed to approve PolicyExceptions", req.UserInfo.Username))}
+	}
+
+	var allErrs field.ErrorList
+	if approvedBy != req.UserInfo.Username {
+		allErrs = append(allErrs, field.Invalid(approvedByPath, approvedBy, fmt.Sprintf("must be set to the approver %s", req.UserInfo.Username)))
+	}
+
+	specHash, err := controller.SpecHash(gsPolicyException)
+	if err != nil {
+		return append(allErrs, field.InternalError(approvedSpecHashPath, err))
+	}
+	if approvedSpecHash != specHash {
+		allErrs = append(allErrs, field.Invalid(approvedSpecHashPath, approvedSpecHash, fmt.Sprintf("does not match the spec hash %s", specHash)))
+	}
+
+	return allErrs
+}
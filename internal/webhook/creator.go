@@ -0,0 +1,83 @@
+package webhook
+
+import (
+	"context"
+	"net/http"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/giantswarm/kyverno-policy-operator/internal/controller"
+)
+
+// Paths the CreatorRecorder is served on.
+const (
+	PolicyExceptionCreatorPath = "/mutate-policy-giantswarm-io-v1alpha1-policyexception"
+	PolicyManifestCreatorPath  = "/mutate-policy-giantswarm-io-v1alpha1-policymanifest"
+)
+
+// CreatorRecorder records the user who created a Giant Swarm PolicyException or PolicyManifest in the created-by annotation.
+// The annotation is always taken from the admission request and set once, clients can neither choose nor change it.
+type CreatorRecorder struct{}
+
+//+kubebuilder:webhook:path=/mutate-policy-giantswarm-io-v1alpha1-policyexception,mutating=true,failurePolicy=fail,sideEffects=None,groups=policy.giantswarm.io,resources=policyexceptions,verbs=create;update,versions=v1alpha1,name=mpolicyexception.policy.giantswarm.io,admissionReviewVersions=v1
+//+kubebuilder:webhook:path=/mutate-policy-giantswarm-io-v1alpha1-policymanifest,mutating=true,failurePolicy=fail,sideEffects=None,groups=policy.giantswarm.io,resources=policymanifests,verbs=create;update,versions=v1alpha1,name=mpolicymanifest.policy.giantswarm.io,admissionReviewVersions=v1
+
+var _ admission.Handler = &CreatorRecorder{}
+
+// Handle implements admission.Handler.
+// Objects are patched as unstructured, so fields missing from the Policy API types, e.g. the status, are kept.
+func (c *CreatorRecorder) Handle(ctx context.Context, req admission.Request) admission.Response {
+	obj := unstructured.Unstructured{}
+	if err := obj.UnmarshalJSON(req.Object.Raw); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+	createdBy := obj.GetAnnotations()[controller.CreatedByAnnotation]
+
+	switch req.Operation {
+	case admissionv1.Create:
+		// A creator named by the client is overwritten, it would be copied to the Kyverno PolicyException as provenance
+		if createdBy == req.UserInfo.Username {
+			return admission.Allowed("")
+		}
+		createdBy = req.UserInfo.Username
+	case admissionv1.Update:
+		oldObj := unstructured.Unstructured{}
+		if err := oldObj.UnmarshalJSON(req.OldObject.Raw); err != nil {
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+		// Objects created before the webhook was enabled have no creator, none can be added later
+		oldCreatedBy := oldObj.GetAnnotations()[controller.CreatedByAnnotation]
+		if oldCreatedBy == createdBy {
+			return admission.Allowed("")
+		}
+		createdBy = oldCreatedBy
+	default:
+		return admission.Allowed("")
+	}
+
+	annotations := obj.GetAnnotations()
+	if createdBy == "" {
+		delete(annotations, controller.CreatedByAnnotation)
+	} else {
+		if annotations == nil {
+			annotations = make(map[string]string)
+		}
+		annotations[controller.CreatedByAnnotation] = createdBy
+	}
+	obj.SetAnnotations(annotations)
+
+	marshalled, err := obj.MarshalJSON()
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+
+	return admission.PatchResponseFromRaw(req.Object.Raw, marshalled)
+}
+
+// SetupWithManager registers the webhook on path with the Manager, e.g. PolicyExceptionCreatorPath.
+func (c *CreatorRecorder) SetupWithManager(mgr ctrl.Manager, path string) {
+	mgr.GetWebhookServer().Register(path, &admission.Webhook{Handler: c})
+}
@@ -0,0 +1,132 @@
+package webhook_test
+
+import (
+	"context"
+	"encoding/json"
+
+	policyAPI "github.com/giantswarm/policy-api/api/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/giantswarm/kyverno-policy-operator/internal/controller"
+	"github.com/giantswarm/kyverno-policy-operator/internal/webhook"
+)
+
+var _ = Describe("Recording the creator", func() {
+	var (
+		ctx               context.Context
+		recorder          *webhook.CreatorRecorder
+		gsPolicyException *policyAPI.PolicyException
+	)
+
+	rawObject := func(obj *policyAPI.PolicyException) runtime.RawExtension {
+		data, err := json.Marshal(obj)
+		Expect(err).NotTo(HaveOccurred())
+		return runtime.RawExtension{Raw: data}
+	}
+	request := func(operation admissionv1.Operation, username string, oldObj, obj *policyAPI.PolicyException) admission.Request {
+		req := admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+			Operation: operation,
+			UserInfo:  authenticationv1.UserInfo{Username: username},
+			Object:    rawObject(obj),
+		}}
+		if oldObj != nil {
+			req.OldObject = rawObject(oldObj)
+		}
+		return req
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		recorder = &webhook.CreatorRecorder{}
+
+		gsPolicyException = &policyAPI.PolicyException{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: policyAPI.GroupVersion.String(),
+				Kind:       controller.PolicyExceptionKind,
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-policyexception",
+				Namespace: "default",
+			},
+			Spec: policyAPI.PolicyExceptionSpec{
+				Targets:  []policyAPI.Target{{Namespaces: []string{"default"}, Names: []string{"test-app-1"}, Kind: "Deployment"}},
+				Policies: []string{"disallow-privileged-containers"},
+			},
+		}
+	})
+
+	It("should record the user creating the PolicyException", func() {
+		response := recorder.Handle(ctx, request(admissionv1.Create, "jane@example.com", nil, gsPolicyException))
+		Expect(response.Allowed).To(BeTrue())
+		Expect(response.Patches).To(ConsistOf(SatisfyAll(
+			HaveField("Operation", "add"),
+			HaveField("Path", "/metadata/annotations"),
+			HaveField("Value", HaveKeyWithValue(controller.CreatedByAnnotation, "jane@example.com")),
+		)))
+	})
+
+	It("should overwrite a creator named by the client", func() {
+		gsPolicyException.Annotations = map[string]string{controller.CreatedByAnnotation: "jane@example.com"}
+
+		response := recorder.Handle(ctx, request(admissionv1.Create, "system:serviceaccount:flux-system:kustomize-controller", nil, gsPolicyException))
+		Expect(response.Allowed).To(BeTrue())
+		Expect(response.Patches).To(ConsistOf(SatisfyAll(
+			HaveField("Operation", "replace"),
+			HaveField("Path", "/metadata/annotations/policy.giantswarm.io~1created-by"),
+			HaveField("Value", "system:serviceaccount:flux-system:kustomize-controller"),
+		)))
+	})
+
+	It("should keep the creator when another user updates the PolicyException", func() {
+		oldGSPolicyException := gsPolicyException.DeepCopy()
+		oldGSPolicyException.Annotations = map[string]string{controller.CreatedByAnnotation: "jane@example.com"}
+
+		// A later apply by someone else drops or replaces the annotation
+		gsPolicyException.Spec.Targets[0].Names = []string{"test-app-2"}
+		gsPolicyException.Annotations = map[string]string{controller.CreatedByAnnotation: "john@example.com"}
+
+		response := recorder.Handle(ctx, request(admissionv1.Update, "john@example.com", oldGSPolicyException, gsPolicyException))
+		Expect(response.Allowed).To(BeTrue())
+		Expect(response.Patches).To(ConsistOf(SatisfyAll(
+			HaveField("Operation", "replace"),
+			HaveField("Path", "/metadata/annotations/policy.giantswarm.io~1created-by"),
+			HaveField("Value", "jane@example.com"),
+		)))
+
+		gsPolicyException.Annotations = nil
+		response = recorder.Handle(ctx, request(admissionv1.Update, "john@example.com", oldGSPolicyException, gsPolicyException))
+		Expect(response.Allowed).To(BeTrue())
+		Expect(response.Patches).To(ConsistOf(SatisfyAll(
+			HaveField("Operation", "add"),
+			HaveField("Path", "/metadata/annotations"),
+			HaveField("Value", HaveKeyWithValue(controller.CreatedByAnnotation, "jane@example.com")),
+		)))
+	})
+
+	It("should not guess the creator of PolicyExceptions created before the webhook", func() {
+		oldGSPolicyException := gsPolicyException.DeepCopy()
+		gsPolicyException.Spec.Targets[0].Names = []string{"test-app-2"}
+
+		response := recorder.Handle(ctx, request(admissionv1.Update, "john@example.com", oldGSPolicyException, gsPolicyException))
+		Expect(response.Allowed).To(BeTrue())
+		Expect(response.Patches).To(BeEmpty())
+	})
+
+	It("should not let updates add a creator to PolicyExceptions created before the webhook", func() {
+		oldGSPolicyException := gsPolicyException.DeepCopy()
+		gsPolicyException.Annotations = map[string]string{controller.CreatedByAnnotation: "john@example.com"}
+
+		response := recorder.Handle(ctx, request(admissionv1.Update, "john@example.com", oldGSPolicyException, gsPolicyException))
+		Expect(response.Allowed).To(BeTrue())
+		Expect(response.Patches).To(ConsistOf(SatisfyAll(
+			HaveField("Operation", "remove"),
+			HaveField("Path", "/metadata/annotations/policy.giantswarm.io~1created-by"),
+		)))
+	})
+})
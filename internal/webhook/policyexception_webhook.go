@@ -0,0 +1,96 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	policyAPI "github.com/giantswarm/policy-api/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/giantswarm/kyverno-policy-operator/internal/controller"
+)
+
+// PolicyExceptionValidator validates Giant Swarm PolicyExceptions at admission time
+type PolicyExceptionValidator struct {
+	Client     client.Reader
+	RESTMapper meta.RESTMapper
+	// RequireApproval restricts the approval annotations to the Approvers.
+	RequireApproval bool
+	Approvers       Approvers
+}
+
+//+kubebuilder:webhook:path=/validate-policy-giantswarm-io-v1alpha1-policyexception,mutating=false,failurePolicy=fail,sideEffects=None,groups=policy.giantswarm.io,resources=policyexceptions,verbs=create;update,versions=v1alpha1,name=vpolicyexception.policy.giantswarm.io,admissionReviewVersions=v1
+
+var _ admission.Validator[*policyAPI.PolicyException] = &PolicyExceptionValidator{}
+
+// ValidateCreate implements admission.Validator.
+func (v *PolicyExceptionValidator) ValidateCreate(ctx context.Context, gsPolicyException *policyAPI.PolicyException) (admission.Warnings, error) {
+	return v.validate(ctx, nil, gsPolicyException)
+}
+
+// ValidateUpdate implements admission.Validator.
+// Updates which don't touch the spec, e.g. finalizer removal by the operator, are always allowed so retired policies can't block them.
+func (v *PolicyExceptionValidator) ValidateUpdate(ctx context.Context, oldGSPolicyException, gsPolicyException *policyAPI.PolicyException) (admission.Warnings, error) {
+	if !gsPolicyException.DeletionTimestamp.IsZero() ||
+		(equality.Semantic.DeepEqual(oldGSPolicyException.Spec, gsPolicyException.Spec) &&
+			equality.Semantic.DeepEqual(oldGSPolicyException.Annotations, gsPolicyException.Annotations)) {
+		return nil, nil
+	}
+
+	return v.validate(ctx, oldGSPolicyException, gsPolicyException)
+}
+
+// ValidateDelete implements admission.Validator.
+func (v *PolicyExceptionValidator) ValidateDelete(ctx context.Context, gsPolicyException *policyAPI.PolicyException) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (v *PolicyExceptionValidator) validate(ctx context.Context, oldGSPolicyException, gsPolicyException *policyAPI.PolicyException) (admission.Warnings, error) {
+	specPath := field.NewPath("spec")
+
+	warnings, allErrs := validateTargets(gsPolicyException.Spec.Targets, v.RESTMapper, specPath.Child("targets"))
+
+	// An unreadable expiry deletes the Kyverno PolicyException, reject it before it's stored
+	if expiresAt, ok := gsPolicyException.Annotations[controller.ExpiresAtAnnotation]; ok {
+		if _, err := time.Parse(time.RFC3339, expiresAt); err != nil {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("metadata", "annotations").Key(controller.ExpiresAtAnnotation), expiresAt, "must be an RFC 3339 timestamp"))
+		}
+	}
+
+	if v.RequireApproval {
+		allErrs = append(allErrs, v.Approvers.validateApproval(ctx, oldGSPolicyException, gsPolicyException)...)
+	}
+
+	missingPolicies, policyErrs := validatePolicies(ctx, v.Client, gsPolicyException.Spec.Policies, specPath.Child("policies"))
+	allErrs = append(allErrs, policyErrs...)
+	if len(missingPolicies) != 0 {
+		message := fmt.Sprintf("policies %s don't exist", strings.Join(missingPolicies, ", "))
+		// Partial resolution tolerates missing policies, they are added once they exist
+		if gsPolicyException.Annotations[controller.ResolutionModeAnnotation] == controller.ResolutionModePartial {
+			warnings = append(warnings, message)
+		} else {
+			allErrs = append(allErrs, field.Invalid(specPath.Child("policies"), missingPolicies, message))
+		}
+	}
+
+	if len(allErrs) != 0 {
+		return warnings, apierrors.NewInvalid(policyAPI.GroupVersion.WithKind(controller.PolicyExceptionKind).GroupKind(), gsPolicyException.Name, allErrs)
+	}
+
+	return warnings, nil
+}
+
+// SetupWithManager registers the webhook with the Manager.
+func (v *PolicyExceptionValidator) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr, &policyAPI.PolicyException{}).
+		WithValidator(v).
+		Complete()
+}
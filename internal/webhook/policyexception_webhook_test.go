@@ -0,0 +1,213 @@
+package webhook_test
+
+import (
+	"context"
+
+	policyAPI "github.com/giantswarm/policy-api/api/v1alpha1"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/giantswarm/kyverno-policy-operator/internal/controller"
+	"github.com/giantswarm/kyverno-policy-operator/internal/webhook"
+)
+
+var _ = Describe("Validating GSPolicyExceptions", func() {
+	var (
+		ctx               context.Context
+		validator         *webhook.PolicyExceptionValidator
+		gsPolicyException *policyAPI.PolicyException
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+
+		scheme := runtime.NewScheme()
+		Expect(kyvernov1.AddToScheme(scheme)).To(Succeed())
+		Expect(policyAPI.AddToScheme(scheme)).To(Succeed())
+
+		restMapper := meta.NewDefaultRESTMapper(nil)
+		restMapper.Add(schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}, meta.RESTScopeNamespace)
+		restMapper.Add(schema.GroupVersionKind{Version: "v1", Kind: "Pod"}, meta.RESTScopeNamespace)
+
+		validator = &webhook.PolicyExceptionValidator{
+			Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+				&kyvernov1.ClusterPolicy{ObjectMeta: metav1.ObjectMeta{Name: "disallow-privileged-containers"}},
+				&kyvernov1.Policy{ObjectMeta: metav1.ObjectMeta{Name: "require-resource-limits", Namespace: "my-team"}},
+			).Build(),
+			RESTMapper: restMapper,
+		}
+
+		gsPolicyException = &policyAPI.PolicyException{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-policyexception",
+				Namespace: "default",
+			},
+			Spec: policyAPI.PolicyExceptionSpec{
+				Targets: []policyAPI.Target{
+					{
+						Namespaces: []string{"default"},
+						Names:      []string{"test-app-1"},
+						Kind:       "Deployment",
+					},
+				},
+				Policies: []string{"disallow-privileged-containers", "my-team/require-resource-limits"},
+			},
+		}
+	})
+
+	It("should accept a valid PolicyException", func() {
+		warnings, err := validator.ValidateCreate(ctx, gsPolicyException)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(warnings).To(BeEmpty())
+	})
+
+	It("should reject empty names", func() {
+		gsPolicyException.Spec.Targets[0].Names = []string{""}
+
+		_, err := validator.ValidateCreate(ctx, gsPolicyException)
+		Expect(apierrors.IsInvalid(err)).To(BeTrue())
+		Expect(err.Error()).To(ContainSubstring("spec.targets[0].names[0]"))
+	})
+
+	It("should reject kinds the cluster doesn't serve", func() {
+		gsPolicyException.Spec.Targets[0].Kind = "Deploymnet"
+
+		_, err := validator.ValidateCreate(ctx, gsPolicyException)
+		Expect(apierrors.IsInvalid(err)).To(BeTrue())
+		Expect(err.Error()).To(ContainSubstring("kind Deploymnet is not served by the cluster"))
+	})
+
+	It("should accept fully qualified kinds", func() {
+		gsPolicyException.Spec.Targets[0].Kind = "apps/v1/Deployment"
+
+		_, err := validator.ValidateCreate(ctx, gsPolicyException)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should reject policies that don't exist", func() {
+		gsPolicyException.Spec.Policies = append(gsPolicyException.Spec.Policies, "retired-policy")
+
+		_, err := validator.ValidateCreate(ctx, gsPolicyException)
+		Expect(apierrors.IsInvalid(err)).To(BeTrue())
+		Expect(err.Error()).To(ContainSubstring("policies retired-policy don't exist"))
+	})
+
+	It("should only warn about missing policies in partial resolution mode", func() {
+		gsPolicyException.Annotations = map[string]string{controller.ResolutionModeAnnotation: controller.ResolutionModePartial}
+		gsPolicyException.Spec.Policies = append(gsPolicyException.Spec.Policies, "retired-policy")
+
+		warnings, err := validator.ValidateCreate(ctx, gsPolicyException)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(warnings).To(ContainElement(ContainSubstring("retired-policy")))
+	})
+
+	It("should reject duplicate targets", func() {
+		duplicate := gsPolicyException.Spec.Targets[0]
+		gsPolicyException.Spec.Targets = append(gsPolicyException.Spec.Targets, duplicate)
+
+		_, err := validator.ValidateCreate(ctx, gsPolicyException)
+		Expect(apierrors.IsInvalid(err)).To(BeTrue())
+		Expect(err.Error()).To(ContainSubstring("spec.targets[1]"))
+	})
+
+	It("should reject invalid expiries", func() {
+		gsPolicyException.Annotations = map[string]string{controller.ExpiresAtAnnotation: "never"}
+
+		_, err := validator.ValidateCreate(ctx, gsPolicyException)
+		Expect(apierrors.IsInvalid(err)).To(BeTrue())
+		Expect(err.Error()).To(ContainSubstring("must be an RFC 3339 timestamp"))
+	})
+
+	Context("When approval is required", func() {
+		var approverCtx context.Context
+
+		BeforeEach(func() {
+			validator.RequireApproval = true
+			validator.Approvers = webhook.Approvers{Groups: []string{"security-team"}}
+
+			approverCtx = admission.NewContextWithRequest(ctx, admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+				UserInfo: authenticationv1.UserInfo{Username: "alice", Groups: []string{"security-team"}},
+			}})
+		})
+
+		approve := func(approvedBy string) {
+			specHash, err := controller.SpecHash(gsPolicyException)
+			Expect(err).NotTo(HaveOccurred())
+			gsPolicyException.Annotations = map[string]string{
+				controller.ApprovedSpecHashAnnotation: specHash,
+				controller.ApprovedByAnnotation:       approvedBy,
+			}
+		}
+
+		It("should accept approvals from approvers", func() {
+			approve("alice")
+
+			_, err := validator.ValidateCreate(approverCtx, gsPolicyException)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should reject approvals from other users", func() {
+			approve("bob")
+			userCtx := admission.NewContextWithRequest(ctx, admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+				UserInfo: authenticationv1.UserInfo{Username: "bob", Groups: []string{"developers"}},
+			}})
+
+			_, err := validator.ValidateCreate(userCtx, gsPolicyException)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring("bob is not allowed to approve PolicyExceptions"))
+		})
+
+		It("should reject approvals in the name of someone else", func() {
+			approve("bob")
+
+			_, err := validator.ValidateCreate(approverCtx, gsPolicyException)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring("must be set to the approver alice"))
+		})
+
+		It("should reject approvals for another spec", func() {
+			approve("alice")
+			gsPolicyException.Spec.Targets[0].Names = append(gsPolicyException.Spec.Targets[0].Names, "test-app-2")
+
+			_, err := validator.ValidateCreate(approverCtx, gsPolicyException)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring("does not match the spec hash"))
+		})
+
+		It("should reject keeping an approval while changing the expiry", func() {
+			approve("alice")
+			oldGSPolicyException := gsPolicyException.DeepCopy()
+			gsPolicyException.Annotations[controller.ExpiresAtAnnotation] = "2030-01-01T00:00:00Z"
+			userCtx := admission.NewContextWithRequest(ctx, admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+				UserInfo: authenticationv1.UserInfo{Username: "bob", Groups: []string{"developers"}},
+			}})
+
+			// The stale approval is kept, the controller waits for a new one
+			_, err := validator.ValidateUpdate(userCtx, oldGSPolicyException, gsPolicyException)
+			Expect(err).NotTo(HaveOccurred())
+
+			specHash, err := controller.SpecHash(gsPolicyException)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(gsPolicyException.Annotations[controller.ApprovedSpecHashAnnotation]).NotTo(Equal(specHash))
+		})
+	})
+
+	It("should allow updates which don't change the spec", func() {
+		gsPolicyException.Spec.Policies = []string{"retired-policy"}
+		updated := gsPolicyException.DeepCopy()
+		updated.Finalizers = nil
+
+		_, err := validator.ValidateUpdate(ctx, gsPolicyException, updated)
+		Expect(err).NotTo(HaveOccurred())
+	})
+})
@@ -0,0 +1,74 @@
+package webhook
+
+import (
+	"context"
+
+	policyAPI "github.com/giantswarm/policy-api/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/giantswarm/kyverno-policy-operator/internal/controller"
+)
+
+// PolicyManifestValidator validates PolicyManifests at admission time
+type PolicyManifestValidator struct {
+	RESTMapper meta.RESTMapper
+}
+
+//+kubebuilder:webhook:path=/validate-policy-giantswarm-io-v1alpha1-policymanifest,mutating=false,failurePolicy=fail,sideEffects=None,groups=policy.giantswarm.io,resources=policymanifests,verbs=create;update,versions=v1alpha1,name=vpolicymanifest.policy.giantswarm.io,admissionReviewVersions=v1
+
+var _ admission.Validator[*policyAPI.PolicyManifest] = &PolicyManifestValidator{}
+
+// ValidateCreate implements admission.Validator.
+func (v *PolicyManifestValidator) ValidateCreate(ctx context.Context, polman *policyAPI.PolicyManifest) (admission.Warnings, error) {
+	return v.validate(polman)
+}
+
+// ValidateUpdate implements admission.Validator.
+func (v *PolicyManifestValidator) ValidateUpdate(ctx context.Context, oldPolman, polman *policyAPI.PolicyManifest) (admission.Warnings, error) {
+	if !polman.DeletionTimestamp.IsZero() || equality.Semantic.DeepEqual(oldPolman.Spec, polman.Spec) {
+		return nil, nil
+	}
+
+	return v.validate(polman)
+}
+
+// ValidateDelete implements admission.Validator.
+func (v *PolicyManifestValidator) ValidateDelete(ctx context.Context, polman *policyAPI.PolicyManifest) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validate checks the targets and the mode of the PolicyManifest.
+// The ClusterPolicy itself isn't required to exist since PolicyManifests are usually shipped together with it.
+func (v *PolicyManifestValidator) validate(polman *policyAPI.PolicyManifest) (admission.Warnings, error) {
+	specPath := field.NewPath("spec")
+
+	warnings, allErrs := validateTargets(polman.Spec.Exceptions, v.RESTMapper, specPath.Child("exceptions"))
+	automatedWarnings, automatedErrs := validateTargets(polman.Spec.AutomatedExceptions, v.RESTMapper, specPath.Child("automatedExceptions"))
+	warnings = append(warnings, automatedWarnings...)
+	allErrs = append(allErrs, automatedErrs...)
+
+	switch polman.Spec.Mode {
+	case "", controller.ModeEnforce, controller.ModeAudit, controller.ModeWarming:
+	default:
+		allErrs = append(allErrs, field.NotSupported(specPath.Child("mode"), polman.Spec.Mode,
+			[]string{controller.ModeEnforce, controller.ModeAudit, controller.ModeWarming}))
+	}
+
+	if len(allErrs) != 0 {
+		return warnings, apierrors.NewInvalid(policyAPI.GroupVersion.WithKind(controller.PolicyManifestKind).GroupKind(), polman.Name, allErrs)
+	}
+
+	return warnings, nil
+}
+
+// SetupWithManager registers the webhook with the Manager.
+func (v *PolicyManifestValidator) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr, &policyAPI.PolicyManifest{}).
+		WithValidator(v).
+		Complete()
+}
@@ -0,0 +1,63 @@
+package webhook_test
+
+import (
+	"context"
+
+	policyAPI "github.com/giantswarm/policy-api/api/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/giantswarm/kyverno-policy-operator/internal/controller"
+	"github.com/giantswarm/kyverno-policy-operator/internal/webhook"
+)
+
+var _ = Describe("Validating PolicyManifests", func() {
+	var (
+		ctx       context.Context
+		validator *webhook.PolicyManifestValidator
+		polman    *policyAPI.PolicyManifest
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+
+		restMapper := meta.NewDefaultRESTMapper(nil)
+		restMapper.Add(schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}, meta.RESTScopeNamespace)
+
+		validator = &webhook.PolicyManifestValidator{RESTMapper: restMapper}
+
+		polman = &policyAPI.PolicyManifest{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "disallow-privileged-containers",
+			},
+			Spec: policyAPI.PolicyManifestSpec{
+				Mode: controller.ModeWarming,
+				Exceptions: []policyAPI.Target{
+					{
+						Namespaces: []string{"default"},
+						Names:      []string{"test-app-1"},
+						Kind:       "Deployment",
+					},
+				},
+			},
+		}
+	})
+
+	It("should accept a valid PolicyManifest", func() {
+		warnings, err := validator.ValidateCreate(ctx, polman)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(warnings).To(BeEmpty())
+	})
+
+	It("should reject unknown modes", func() {
+		polman.Spec.Mode = "Enforce"
+
+		_, err := validator.ValidateCreate(ctx, polman)
+		Expect(apierrors.IsInvalid(err)).To(BeTrue())
+		Expect(err.Error()).To(ContainSubstring("spec.mode"))
+	})
+})
@@ -0,0 +1,142 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	policyAPI "github.com/giantswarm/policy-api/api/v1alpha1"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// validateTargets rejects targets the operator can't translate safely: empty names, unknown kinds and duplicates.
+// Targets matching every namespace are allowed but reported as a warning.
+func validateTargets(targets []policyAPI.Target, mapper meta.RESTMapper, fldPath *field.Path) (admission.Warnings, field.ErrorList) {
+	var warnings admission.Warnings
+	var allErrs field.ErrorList
+
+	seen := make(map[string]int)
+	for i, target := range targets {
+		targetPath := fldPath.Index(i)
+
+		if target.Kind == "" {
+			allErrs = append(allErrs, field.Required(targetPath.Child("kind"), "a kind is required"))
+		} else if err := validateKind(target.Kind, mapper); err != nil {
+			allErrs = append(allErrs, field.Invalid(targetPath.Child("kind"), target.Kind, err.Error()))
+		}
+
+		// An empty name list would match every object of the kind
+		if len(target.Names) == 0 {
+			allErrs = append(allErrs, field.Required(targetPath.Child("names"), "at least one name is required"))
+		}
+		for j, name := range target.Names {
+			if strings.TrimSpace(name) == "" {
+				allErrs = append(allErrs, field.Required(targetPath.Child("names").Index(j), "names must not be empty"))
+			}
+		}
+
+		if len(target.Namespaces) == 0 {
+			warnings = append(warnings, fmt.Sprintf("%s has no namespaces and matches %s %s in every namespace",
+				targetPath, target.Kind, strings.Join(target.Names, ", ")))
+		}
+
+		key := targetKey(target)
+		if first, exists := seen[key]; exists {
+			allErrs = append(allErrs, field.Duplicate(targetPath, fmt.Sprintf("same target as %s", fldPath.Index(first))))
+		} else {
+			seen[key] = i
+		}
+	}
+
+	return warnings, allErrs
+}
+
+// validateKind checks that a Kyverno kind selector, e.g. Deployment, v1/Pod or apps/v1/Deployment, is served by the cluster.
+func validateKind(kind string, mapper meta.RESTMapper) error {
+	// Wildcards are resolved by Kyverno
+	if strings.Contains(kind, "*") {
+		return nil
+	}
+
+	var gvr schema.GroupVersionResource
+	parts := strings.Split(kind, "/")
+	switch len(parts) {
+	case 1:
+		gvr.Resource = parts[0]
+	case 2:
+		// Either Version/Kind or Kind/Subresource
+		if parts[0] != "" && strings.ToLower(parts[0][:1]) == parts[0][:1] {
+			gvr.Version, gvr.Resource = parts[0], parts[1]
+		} else {
+			gvr.Resource = parts[0]
+		}
+	case 3, 4:
+		gvr.Group, gvr.Version, gvr.Resource = parts[0], parts[1], parts[2]
+	default:
+		return fmt.Errorf("expected Kind, Version/Kind or Group/Version/Kind")
+	}
+	kindName := gvr.Resource
+	gvr.Resource = strings.ToLower(gvr.Resource)
+
+	gvks, err := mapper.KindsFor(gvr)
+	if err != nil && !meta.IsNoMatchError(err) {
+		return err
+	}
+	for _, gvk := range gvks {
+		if gvk.Kind == kindName {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("kind %s is not served by the cluster", kind)
+}
+
+// targetKey identifies a target regardless of the order of its names and namespaces.
+func targetKey(target policyAPI.Target) string {
+	names := slices.Sorted(slices.Values(target.Names))
+	namespaces := slices.Sorted(slices.Values(target.Namespaces))
+
+	return fmt.Sprintf("%s|%s|%s", target.Kind, strings.Join(namespaces, ","), strings.Join(names, ","))
+}
+
+// validatePolicies reports duplicated entries and policies which don't exist.
+// ClusterPolicies are referenced by name and namespaced Policies as <namespace>/<name>.
+func validatePolicies(ctx context.Context, c client.Reader, policies []string, fldPath *field.Path) (missing []string, allErrs field.ErrorList) {
+	if len(policies) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath, "at least one policy is required"))
+	}
+
+	seen := make(map[string]bool)
+	for i, policy := range policies {
+		if seen[policy] {
+			allErrs = append(allErrs, field.Duplicate(fldPath.Index(i), policy))
+			continue
+		}
+		seen[policy] = true
+
+		var kyvernoPolicy client.Object = &kyvernov1.ClusterPolicy{}
+		key := types.NamespacedName{Name: policy}
+		if namespace, name, namespaced := strings.Cut(policy, "/"); namespaced {
+			kyvernoPolicy = &kyvernov1.Policy{}
+			key = types.NamespacedName{Namespace: namespace, Name: name}
+		}
+
+		if err := c.Get(ctx, key, kyvernoPolicy); err != nil {
+			if !apierrors.IsNotFound(err) {
+				allErrs = append(allErrs, field.InternalError(fldPath.Index(i), err))
+				continue
+			}
+			missing = append(missing, policy)
+		}
+	}
+
+	return missing, allErrs
+}
@@ -17,8 +17,10 @@ limitations under the License.
 package main
 
 import (
+	"encoding/json"
 	"flag"
 	"fmt"
+	"maps"
 	"os"
 	"strings"
 
@@ -30,6 +32,9 @@ import (
 	policyAPI "github.com/giantswarm/policy-api/api/v1alpha1"
 
 	"github.com/giantswarm/kyverno-policy-operator/internal/controller"
+	"github.com/giantswarm/kyverno-policy-operator/internal/metrics"
+	"github.com/giantswarm/kyverno-policy-operator/internal/policycache"
+	"github.com/giantswarm/kyverno-policy-operator/internal/webhook"
 
 	kyvernov2 "github.com/kyverno/kyverno/api/kyverno/v2"
 
@@ -37,11 +42,17 @@ import (
 
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/discovery/cached/memory"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/restmapper"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/server"
+	ctrlwebhook "sigs.k8s.io/controller-runtime/pkg/webhook"
 	//+kubebuilder:scaffold:imports
 )
 
@@ -50,6 +61,9 @@ var (
 	setupLog = ctrl.Log.WithName("setup")
 )
 
+// policyUpdatesBufferSize is the number of Kyverno policy changes buffered per dependent controller.
+const policyUpdatesBufferSize = 100
+
 func init() {
 	utilruntime.Must(kyvernov2.AddToScheme(scheme))
 	utilruntime.Must(kyvernov1.AddToScheme(scheme))
@@ -67,7 +81,19 @@ func main() {
 	var polmanEnabled bool
 	var chartOperatorExceptionKinds []string
 	var maxJitterPercent int
-	policyCache := make(map[string]kyvernov1.ClusterPolicy)
+	var enableWebhooks bool
+	var webhookPort int
+	var webhookCertDir string
+	var requireApproval bool
+	var approvers webhook.Approvers
+	var destinationRules []controller.DestinationRule
+	var dryRunEnabled bool
+	kindHierarchy := controller.DefaultKindHierarchy()
+	policyCache := policycache.New()
+	// Kyverno policy changes are forwarded to the controllers depending on them
+	var policyUpdates []chan<- event.GenericEvent
+	policyExceptionUpdates := make(chan event.GenericEvent, policyUpdatesBufferSize)
+	policyUpdates = append(policyUpdates, policyExceptionUpdates)
 
 	// Flags
 	flag.StringVar(&destinationNamespace, "destination-namespace", "", "The namespace where the Kyverno PolicyExceptions will be created. Defaults to GS PolicyException namespace.")
@@ -92,6 +118,45 @@ func main() {
 
 			return nil
 		})
+	flag.Func("destination-rules",
+		`A JSON list of rules mapping source namespaces to destination namespaces, e.g. [{"namespaceSelector": {"matchLabels": {"tenant": "acme"}}, "destination": "acme-exceptions"}]. The first matching rule wins, --destination-namespace is the fallback.`,
+		func(input string) error {
+			if err := json.Unmarshal([]byte(input), &destinationRules); err != nil {
+				return err
+			}
+
+			return controller.ValidateDestinationRules(destinationRules)
+		})
+	flag.Func("kind-hierarchy",
+		`A JSON object mapping workload kinds to the kinds their controllers create, e.g. {"Rollout": ["ReplicaSet"]}. Entries are merged over the built-in Kubernetes controllers.`,
+		func(input string) error {
+			customKindHierarchy := controller.KindHierarchy{}
+			if err := json.Unmarshal([]byte(input), &customKindHierarchy); err != nil {
+				return err
+			}
+
+			maps.Copy(kindHierarchy, customKindHierarchy)
+
+			return nil
+		})
+	flag.BoolVar(&enableWebhooks, "enable-webhooks", false, "Serve the webhooks for Giant Swarm PolicyExceptions, and PolicyManifests if enabled.")
+	flag.IntVar(&webhookPort, "webhook-port", 9443, "The port the webhook server binds to.")
+	flag.StringVar(&webhookCertDir, "webhook-cert-dir", "", "The directory containing the webhook server certificate. Defaults to <temp-dir>/k8s-webhook-server/serving-certs.")
+	flag.BoolVar(&requireApproval, "require-approval", false, "Keep Giant Swarm PolicyExceptions pending until an approver accepted their current spec. Requires --enable-webhooks.")
+	flag.Func("approver-groups", "A comma-separated list of groups allowed to approve PolicyExceptions.",
+		func(input string) error {
+			approvers.Groups = append(approvers.Groups, strings.Split(input, ",")...)
+
+			return nil
+		})
+	flag.Func("approver-service-accounts", "A comma-separated list of ServiceAccounts allowed to approve PolicyExceptions, as <namespace>/<name>.",
+		func(input string) error {
+			approvers.ServiceAccounts = append(approvers.ServiceAccounts, strings.Split(input, ",")...)
+
+			return nil
+		})
+	flag.BoolVar(&dryRunEnabled, "dry-run", false,
+		"Compute the Kyverno PolicyExceptions without writing anything. The pending changes are logged, served on /dry-run by the metrics server and counted in the kyverno_policy_operator_dry_run_pending_changes metric.")
 	flag.IntVar(&maxJitterPercent, "max-jitter-percent", 10, "Spreads out re-queue interval by +/- this amount to spread load.")
 	opts.BindFlags(flag.CommandLine)
 	flag.Parse()
@@ -101,14 +166,32 @@ func main() {
 		os.Exit(2) // The flag package uses 2 as the exit code when the program is terminated due to a flag parsing error
 	}
 
+	// Approvals can't be verified without the webhook
+	if requireApproval && !enableWebhooks {
+		fmt.Println("Error: The require-approval flag requires the enable-webhooks flag")
+		os.Exit(2)
+	}
+
 	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
 
+	// A dry-run instance can run next to the active operator, so it uses its own leader election lease
+	leaderElectionID := "71f505ec.giantswarm.io"
+	var dryRun *controller.DryRun
+	if dryRunEnabled {
+		leaderElectionID = "71f505ec-dry-run.giantswarm.io"
+		dryRun = controller.NewDryRun()
+	}
+
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
 		Scheme:                 scheme,
 		Metrics:                server.Options{BindAddress: metricsAddr},
 		HealthProbeBindAddress: probeAddr,
 		LeaderElection:         enableLeaderElection,
-		LeaderElectionID:       "71f505ec.giantswarm.io",
+		LeaderElectionID:       leaderElectionID,
+		WebhookServer: ctrlwebhook.NewServer(ctrlwebhook.Options{
+			Port:    webhookPort,
+			CertDir: webhookCertDir,
+		}),
 		// LeaderElectionReleaseOnCancel defines if the leader should step down voluntarily
 		// when the Manager ends. This requires the binary to immediately end when the
 		// Manager is stopped, otherwise, this setting is unsafe. Setting this significantly
@@ -126,6 +209,16 @@ func main() {
 		os.Exit(1)
 	}
 
+	// Nothing the events describe happens in dry-run mode
+	eventRecorder := mgr.GetEventRecorder(controller.ComponentName)
+	if dryRun != nil {
+		eventRecorder = controller.DiscardEventRecorder{}
+		if err := mgr.AddMetricsServerExtraHandler("/dry-run", dryRun); err != nil {
+			setupLog.Error(err, "unable to serve the dry-run changes")
+			os.Exit(1)
+		}
+	}
+
 	if err = (&controller.PolicyExceptionReconciler{
 		Client:               mgr.GetClient(),
 		Scheme:               mgr.GetScheme(),
@@ -133,6 +226,12 @@ func main() {
 		Background:           backgroundMode,
 		PolicyCache:          policyCache,
 		MaxJitterPercent:     maxJitterPercent,
+		Recorder:             eventRecorder,
+		PolicyUpdates:        policyExceptionUpdates,
+		KindHierarchy:        kindHierarchy,
+		RequireApproval:      requireApproval,
+		DestinationRules:     destinationRules,
+		DryRun:               dryRun,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "PolicyException")
 		os.Exit(1)
@@ -140,6 +239,8 @@ func main() {
 
 	if polmanEnabled {
 		setupLog.Info("PolicyManifests enabled, setting up PolicyManifest controller")
+		policyManifestUpdates := make(chan event.GenericEvent, policyUpdatesBufferSize)
+		policyUpdates = append(policyUpdates, policyManifestUpdates)
 		if err = (&controller.PolicyManifestReconciler{
 			Client:               mgr.GetClient(),
 			Scheme:               mgr.GetScheme(),
@@ -147,6 +248,10 @@ func main() {
 			Background:           backgroundMode,
 			PolicyCache:          policyCache,
 			MaxJitterPercent:     maxJitterPercent,
+			Recorder:             eventRecorder,
+			PolicyUpdates:        policyManifestUpdates,
+			KindHierarchy:        kindHierarchy,
+			DryRun:               dryRun,
 		}).SetupWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create controller", "controller", "PolicyManifest")
 			os.Exit(1)
@@ -160,13 +265,65 @@ func main() {
 		ChartOperatorExceptionKinds: chartOperatorExceptionKinds,
 		PolicyCache:                 policyCache,
 		MaxJitterPercent:            maxJitterPercent,
+		Recorder:                    eventRecorder,
+		PolicyUpdates:               policyUpdates,
+		DryRun:                      dryRun,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "PolicyException")
 		os.Exit(1)
 	}
 
+	if err = (&controller.PolicyReconciler{
+		Client:           mgr.GetClient(),
+		Scheme:           mgr.GetScheme(),
+		PolicyCache:      policyCache,
+		MaxJitterPercent: maxJitterPercent,
+		PolicyUpdates:    policyUpdates,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "Policy")
+		os.Exit(1)
+	}
+
+	if enableWebhooks {
+		// Kinds are resolved through discovery so CRDs are found regardless of their group
+		discoveryClient, err := discovery.NewDiscoveryClientForConfig(mgr.GetConfig())
+		if err != nil {
+			setupLog.Error(err, "unable to create discovery client")
+			os.Exit(1)
+		}
+		restMapper := restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(discoveryClient))
+
+		(&webhook.CreatorRecorder{}).SetupWithManager(mgr, webhook.PolicyExceptionCreatorPath)
+		if err = (&webhook.PolicyExceptionValidator{
+			Client:          mgr.GetClient(),
+			RESTMapper:      restMapper,
+			RequireApproval: requireApproval,
+			Approvers:       approvers,
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "PolicyException")
+			os.Exit(1)
+		}
+		// PolicyManifests which aren't reconciled must not be rejected either
+		if polmanEnabled {
+			(&webhook.CreatorRecorder{}).SetupWithManager(mgr, webhook.PolicyManifestCreatorPath)
+			if err = (&webhook.PolicyManifestValidator{
+				RESTMapper: restMapper,
+			}).SetupWithManager(mgr); err != nil {
+				setupLog.Error(err, "unable to create webhook", "webhook", "PolicyManifest")
+				os.Exit(1)
+			}
+		}
+	}
+
 	//+kubebuilder:scaffold:builder
 
+	// Managed Kyverno PolicyExceptions are counted from the cache at scrape time
+	ctrlmetrics.Registry.MustRegister(&metrics.PolicyExceptionCollector{
+		Reader:    mgr.GetCache(),
+		Labels:    map[string]string{controller.ManagedBy: controller.ComponentName},
+		TypeLabel: controller.ExceptionType,
+	})
+
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
 		setupLog.Error(err, "unable to set up health check")
 		os.Exit(1)